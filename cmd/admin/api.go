@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/env"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/services/game"
+)
+
+// 라이브 방은 API 프로세스의 메모리에만 있으므로 admin API를 호출한다.
+// 지정한 운영자 계정으로 짧은 세션을 만들고, 끝나면 바로 폐기한다.
+type apiClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// apiFlags adds the flags shared by commands that call the API.
+func apiFlags(fs *flag.FlagSet, e *cli) (as *string, apiURL *string) {
+	as = fs.String("as", "", "id or email of the operator to act as (required)")
+	apiURL = fs.String("api", env.GetString("ADMIN_API_URL", e.cfg.PublicURL), "base URL of the API")
+	return as, apiURL
+}
+
+// withAPI signs in as the operator for the duration of fn. The API still
+// checks the operator's permissions and records the actions in the audit log.
+func withAPI(ctx context.Context, e *cli, as, apiURL string, fn func(*apiClient) error) error {
+	if as == "" {
+		return errors.New("-as is required")
+	}
+	user, err := e.findUser(ctx, as)
+	if err != nil {
+		return err
+	}
+
+	authService := e.authService()
+	tokens, err := authService.CreateSession(ctx, mapper.UserMapper(user), "code-racer-admin", "cli")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := authService.RevokeSession(context.Background(), tokens.SessionID); err != nil {
+			e.logger.Warnw("failed to revoke cli session", "sessionID", tokens.SessionID, "error", err)
+		}
+	}()
+
+	return fn(&apiClient{
+		baseURL: strings.TrimRight(apiURL, "/") + "/api/v1",
+		token:   tokens.AccessToken,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	})
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("%s %s: %s (%d)", method, path, apiErr.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+func listRooms(ctx context.Context, e *cli, args []string) error {
+	fs := flag.NewFlagSet("rooms list", flag.ContinueOnError)
+	as, apiURL := apiFlags(fs, e)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return withAPI(ctx, e, *as, *apiURL, func(c *apiClient) error {
+		var resp struct {
+			Rooms []game.RoomSummary `json:"rooms"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/admin/rooms", &resp); err != nil {
+			return err
+		}
+
+		if len(resp.Rooms) == 0 {
+			fmt.Println("no live rooms")
+			return nil
+		}
+		fmt.Printf("%-36s  %-8s  %-7s  %-7s  %s\n", "ID", "STATUS", "HOST", "PROBLEM", "PLAYERS")
+		for _, r := range resp.Rooms {
+			fmt.Printf("%-36s  %-8s  %-7d  %-7d  %v\n", r.ID, r.Status, r.HostID, r.ProblemID, r.PlayerIDs)
+		}
+		return nil
+	})
+}
+
+func closeRoom(ctx context.Context, e *cli, args []string) error {
+	fs := flag.NewFlagSet("rooms close", flag.ContinueOnError)
+	as, apiURL := apiFlags(fs, e)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: rooms close -as <admin> <room-id>")
+	}
+	roomID := fs.Arg(0)
+
+	return withAPI(ctx, e, *as, *apiURL, func(c *apiClient) error {
+		if err := c.do(ctx, http.MethodDelete, "/admin/rooms/"+url.PathEscape(roomID), nil); err != nil {
+			return err
+		}
+		fmt.Printf("closed room %s\n", roomID)
+		return nil
+	})
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/admin"
+	"github.com/Dongmoon29/code_racer_api/internal/services/auth"
+)
+
+// userStore returns the user cache, or nil when Redis is disabled.
+func (e *cli) userStore() cache.UsersRedisStoreInterface {
+	if e.rdb == nil {
+		return nil
+	}
+	return e.storage.Users
+}
+
+func (e *cli) sessionStore() cache.SessionRedisStoreInterface {
+	if e.rdb == nil {
+		return nil
+	}
+	return e.storage.Sessions
+}
+
+func (e *cli) adminService() admin.AdminService {
+	return admin.NewAdminService(
+		e.repository.UserRepository,
+		e.repository.RoleRepository,
+		e.repository.AuditLogRepository,
+		e.repository.ProblemRepository,
+		e.repository.MatchRepository,
+		e.userStore(),
+		e.logger,
+	)
+}
+
+// authService is used for sessions only; nothing here sends mail or signs in
+// with OAuth.
+func (e *cli) authService() auth.AuthService {
+	return auth.NewAuthService(
+		e.repository.UserRepository,
+		e.repository.RoleRepository,
+		e.repository.SessionRepository,
+		e.repository.TwoFactorRepository,
+		e.userStore(),
+		e.sessionStore(),
+		e.storage.RateLimits,
+		e.storage.Tickets,
+		e.storage.LoginAttempts,
+		e.audit,
+		nil,
+		nil,
+		"",
+		"",
+		e.logger,
+	)
+}
+
+// findUser resolves a user id or email.
+func (e *cli) findUser(ctx context.Context, ref string) (*models.User, error) {
+	var user *models.User
+	var err error
+	if id, convErr := strconv.Atoi(ref); convErr == nil {
+		user, err = e.repository.UserRepository.GetByID(ctx, id)
+	} else {
+		user, err = e.repository.UserRepository.GetByEmail(ctx, ref)
+	}
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("user %s not found", ref)
+	}
+	return user, err
+}
+
+func createUser(ctx context.Context, e *cli, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	email := fs.String("email", "", "email address")
+	username := fs.String("username", "", "user name")
+	password := fs.String("password", "", "password (read from stdin when empty)")
+	role := fs.String("role", models.RoleUser, "role name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || *username == "" {
+		return errors.New("-email and -username are required")
+	}
+
+	if *password == "" {
+		// 셸 히스토리에 남지 않도록 표준 입력에서 읽기
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	as := e.adminService()
+	user, err := as.CreateUser(ctx, dtos.SignupRequestDto{
+		Username: *username,
+		Email:    *email,
+		Password: *password,
+	}, *role)
+	if err != nil {
+		return err
+	}
+
+	e.audit.Record(cliEvent(audit.ActionUserCreate).WithTarget("user", user.ID).With("role", *role))
+	fmt.Printf("created user %d (%s) with role %s\n", user.ID, user.Email, *role)
+	return nil
+}
+
+func changeRole(ctx context.Context, e *cli, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: user role <user> <role>")
+	}
+	user, err := e.findUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	as := e.adminService()
+	// 명령줄에서는 실행한 사용자가 없으므로 actor 0
+	change, err := as.ChangeUserRole(ctx, 0, user.ID, args[1])
+	if err != nil {
+		return err
+	}
+
+	e.audit.Record(cliEvent(audit.ActionRoleChange).
+		WithTarget("user", user.ID).
+		With("old_role", change.OldRole).
+		With("new_role", change.NewRole))
+	fmt.Printf("user %d: %s -> %s\n", user.ID, change.OldRole, change.NewRole)
+	return nil
+}
+
+func revokeSessions(ctx context.Context, e *cli, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: user revoke-sessions <user>")
+	}
+	user, err := e.findUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	as := e.authService()
+	if err := as.RevokeUserSessions(ctx, user.ID); err != nil {
+		return err
+	}
+	as.DeleteSession(ctx, int(user.ID))
+
+	e.audit.Record(cliEvent(audit.ActionSessionsRevoke).WithTarget("user", user.ID))
+	fmt.Printf("revoked all sessions of user %d\n", user.ID)
+	return nil
+}
+
+func exportProblems(ctx context.Context, e *cli, args []string) error {
+	fs := flag.NewFlagSet("problems export", flag.ContinueOnError)
+	output := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	as := e.adminService()
+	docs, err := as.ExportProblems(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(docs); err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "exported %d problem(s) to %s\n", len(docs), *output)
+	}
+	return nil
+}
+
+func importProblems(ctx context.Context, e *cli, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: problems import <file>")
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	var docs []admin.ProblemDocument
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return fmt.Errorf("invalid problems file: %w", err)
+	}
+
+	as := e.adminService()
+	result, err := as.ImportProblems(ctx, docs)
+	if result != nil && result.Created+result.Updated > 0 {
+		e.audit.Record(cliEvent(audit.ActionProblemImport).
+			With("created", result.Created).
+			With("updated", result.Updated))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("imported problems: %d created, %d updated\n", result.Created, result.Updated)
+	return nil
+}
+
+func recomputeRatings(ctx context.Context, e *cli, args []string) error {
+	as := e.adminService()
+	replayed, err := as.RecomputeRatings(ctx)
+	if err != nil {
+		return err
+	}
+	e.audit.Record(cliEvent(audit.ActionRatingsRecompute).With("matches", replayed))
+	fmt.Printf("replayed %d rated match(es)\n", replayed)
+
+	// 프로필 캐시에 예전 레이팅이 남지 않도록
+	if e.rdb != nil {
+		if _, err := cache.Flush(ctx, e.rdb, "users"); err != nil {
+			return fmt.Errorf("ratings were updated but flushing the user cache failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func flushCaches(ctx context.Context, e *cli, args []string) error {
+	if e.rdb == nil {
+		return errors.New("redis is disabled (REDIS_ENABLED=false), nothing to flush")
+	}
+
+	names := args
+	if len(names) == 0 {
+		names = cache.DerivedCaches
+	}
+	for _, name := range names {
+		if _, ok := cache.CacheKeyPatterns[name]; !ok {
+			return fmt.Errorf("unknown cache %q (known: %s)", name, strings.Join(cache.CacheNames(), ", "))
+		}
+	}
+
+	for _, name := range names {
+		n, err := cache.Flush(ctx, e.rdb, name)
+		if err != nil {
+			return fmt.Errorf("failed to flush %s: %w", name, err)
+		}
+		fmt.Printf("%s: %d key(s) deleted\n", name, n)
+	}
+	return nil
+}
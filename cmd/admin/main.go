@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/db"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+const usage = `usage: admin <command> [flags] [args]
+
+commands:
+  user create -email E -username U [-password P] [-role R]
+                                create an activated account
+  user role <user> <role>       change a user's role
+  user revoke-sessions <user>   sign a user out everywhere
+  problems export [-o file]     write every problem as JSON
+  problems import <file>        create or replace problems by slug
+  rooms list -as <admin>        list live rooms (via the API)
+  rooms close -as <admin> <id>  force-close a room (via the API)
+  ratings recompute             rebuild Elo ratings from match history
+  cache flush [name...]         flush caches (default: users sessions languages games)
+
+<user> and <admin> are a user id or email. Settings are read from $CONFIG_FILE
+and the same environment variables as the API.
+`
+
+// 명령 실행에 필요한 공통 의존성
+type cli struct {
+	cfg        *config.Config
+	repository repositories.Repository
+	storage    cache.RedisStorage
+	rdb        *redis.Client
+	audit      *audit.Writer
+	logger     *zap.SugaredLogger
+}
+
+type command func(ctx context.Context, e *cli, args []string) error
+
+var commands = map[string]command{
+	"user create":          createUser,
+	"user role":            changeRole,
+	"user revoke-sessions": revokeSessions,
+	"problems export":      exportProblems,
+	"problems import":      importProblems,
+	"rooms list":           listRooms,
+	"rooms close":          closeRoom,
+	"ratings recompute":    recomputeRatings,
+	"cache flush":          flushCaches,
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	name := os.Args[1] + " " + os.Args[2]
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+
+	e, cleanup, err := setup()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	err = run(ctx, e, os.Args[3:])
+	cancel()
+	cleanup()
+
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func setup() (*cli, func(), error) {
+	cfg, err := config.Load("", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	// rooms 명령은 API 가 검증할 수 있는 세션 토큰을 발급함
+	utils.SetSecret(cfg.JWT.Secret)
+
+	logConfig := zap.NewDevelopmentConfig()
+	logConfig.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	logger, err := logConfig.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	sugar := logger.Sugar()
+
+	conn, err := db.New(cfg.DbConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	repository := repositories.NewRepository(conn)
+
+	var rdb *redis.Client
+	if cfg.RedisConfig.Enabled {
+		rdb = cache.NewRedisClient(cfg.RedisConfig.Addr, cfg.RedisConfig.Password, cfg.RedisConfig.Db)
+	}
+
+	writer := audit.NewWriter(repository.AuditLogRepository, 64, sugar)
+	go writer.Run()
+
+	e := &cli{
+		cfg:        cfg,
+		repository: repository,
+		storage:    cache.NewRedisStorage(rdb),
+		rdb:        rdb,
+		audit:      writer,
+		logger:     sugar,
+	}
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := writer.Close(ctx); err != nil {
+			sugar.Errorw("failed to flush audit log", "error", err)
+		}
+		if rdb != nil {
+			rdb.Close()
+		}
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+		sugar.Sync()
+	}
+	return e, cleanup, nil
+}
+
+// cliEvent starts an audit event for an action taken from the command line,
+// where there is no signed-in actor.
+func cliEvent(action string) audit.Event {
+	hostname, _ := os.Hostname()
+	return audit.Event{
+		Action:    action,
+		Success:   true,
+		UserAgent: "code-racer-admin",
+		At:        time.Now(),
+	}.With("source", "cli").With("host", hostname)
+}
@@ -1,47 +1,80 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
 	"github.com/Dongmoon29/code_racer_api/internal/bootstrap"
 	"github.com/Dongmoon29/code_racer_api/internal/config"
 	"github.com/Dongmoon29/code_racer_api/internal/db"
-	"github.com/Dongmoon29/code_racer_api/internal/env"
+	"github.com/Dongmoon29/code_racer_api/internal/health"
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
 	"github.com/Dongmoon29/code_racer_api/internal/services/game"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge0"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/services/users"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/client"
 	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// overrides collects repeated -set flags.
+type overrides []string
+
+func (o *overrides) String() string     { return strings.Join(*o, ", ") }
+func (o *overrides) Set(v string) error { *o = append(*o, v); return nil }
+
 func main() {
-	duration, _ := time.ParseDuration("15m")
-	gameManager := game.NewGameManager()
-	go gameManager.Run()
+	var sets overrides
+	configPath := flag.String("config", "", "YAML or TOML config file (default $CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Var(&sets, "set", "override a setting, e.g. -set game.max_rooms=200 (repeatable)")
+	flag.Parse()
 
-	cfg := &config.Config{
-		DbConfig: config.DbConfig{
-			Host:         env.GetString("DB_HOST", "localhost"),
-			User:         env.GetString("DB_USER", "postgres"),
-			Password:     env.GetString("DB_PASSWORD", "password1234"),
-			Dbname:       env.GetString("DB_NAME", "code_racer_db"),
-			Port:         env.GetInt("DB_PORT", 5432),
-			Timezone:     env.GetString("DB_TIMEZONE", "Asia/seoul"),
-			MaxOpenConns: 10,
-			MaxIdleConns: 5,
-			MaxIdleTime:  duration,
-		},
-		RedisConfig: config.RedisConfig{
-			Addr:     env.GetString("REDIS_ADDR", "localhost:6379"),
-			Enabled:  env.GetBool("REDIS_ENABLED", true),
-			Password: env.GetString("REDIS_PASSWORD", ""),
-			Db:       env.GetInt("REDIS_DB", 0),
-		},
+	cfg, err := config.Load(*configPath, sets)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+	if *printConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			log.Fatalln(err.Error())
+		}
+		if err := cfg.Validate(); err != nil {
+			log.Fatalln(err.Error())
+		}
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalln(err.Error())
+	}
+	utils.SetSecret(cfg.JWT.Secret)
+	client.Configure(client.Judge0Config(cfg.Judge0))
 
-		Addr: env.GetString("ADDR", ":8080"),
-		Env:  env.GetString("ENV", "dev"),
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalln(err.Error())
 	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("failed to flush traces: %v", err)
+		}
+	}()
+
 	db, err := db.New(cfg.DbConfig)
 	if err != nil {
 		log.Fatalln(err.Error())
@@ -60,6 +93,17 @@ func main() {
 	sugar := logger.Sugar()
 	defer sugar.Sync()
 
+	if cfg.DbConfig.AutoMigrate {
+		if err := migrateAndSeed(db, repository, sugar); err != nil {
+			log.Fatalln(err.Error())
+		}
+	}
+
+	mail, err := mailer.New(cfg.Mailer, sugar)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
 	var rdb *redis.Client
 	if cfg.RedisConfig.Enabled {
 		rdb = cache.NewRedisClient(cfg.RedisConfig.Addr, cfg.RedisConfig.Password, cfg.RedisConfig.Db)
@@ -69,16 +113,141 @@ func main() {
 	}
 	cacheStorage := cache.NewRedisStorage(rdb)
 
+	var languageStore cache.LanguagesRedisStoreInterface
+	if cfg.RedisConfig.Enabled {
+		languageStore = cacheStorage.Languages
+	}
+	languagesService := languages.NewLanguagesService(languageStore, cfg.Languages.CacheTTL, sugar)
+	judgeService := judge.NewJudgeService(judge0.NewJudge0Service(sugar), languagesService, sugar)
+
+	// 탈퇴 유예 기간이 끝난 계정 익명화
+	var userStore cache.UsersRedisStoreInterface
+	if cfg.RedisConfig.Enabled {
+		userStore = cacheStorage.Users
+	}
+	usersService := users.NewUsersService(repository.UserRepository, repository.SubmissionRepository, repository.MatchRepository, languagesService, userStore, sugar)
+	go usersService.RunAccountPurger(context.Background(), time.Hour)
+
+	gameManager := game.NewGameManager(repository.ProblemRepository, repository.MatchRepository, judgeService, cfg.Game)
+	go gameManager.Run()
+
+	// 감사 로그는 요청과 별도로 모아서 저장
+	auditWriter := audit.NewWriter(repository.AuditLogRepository, 1024, sugar)
+	go auditWriter.Run()
+
+	checker := newHealthChecker(db, rdb, gameManager)
+
+	if cfg.Metrics.Enabled {
+		registerMetrics(db, rdb, gameManager)
+	}
+
 	app := &config.Application{
 		Logger:       sugar,
 		Config:       cfg,
 		Repository:   repository,
 		CacheStorage: cacheStorage,
 		GameManager:  gameManager,
+		Mailer:       mail,
+		Audit:        auditWriter,
+		Health:       checker,
 	}
 
 	router := bootstrap.Mount(app)
-	if err := bootstrap.Run(app, router); err != nil {
-		log.Fatal(err)
+	runErr := bootstrap.Run(app, router)
+
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := auditWriter.Close(flushCtx); err != nil {
+		sugar.Errorw("failed to flush audit log", "error", err)
+	}
+	cancelFlush()
+
+	if runErr != nil {
+		log.Fatal(runErr)
+	}
+}
+
+// registerMetrics exposes the connection pools and live rooms on /metrics.
+func registerMetrics(gormDB *gorm.DB, rdb *redis.Client, gameManager *game.GameManager) {
+	if sqlDB, err := gormDB.DB(); err == nil {
+		metrics.RegisterDBPool(sqlDB)
+	}
+	if rdb != nil {
+		metrics.RegisterRedisPool(rdb)
+	}
+	metrics.RegisterRooms(gameManager.RoomsByStatus)
+}
+
+// newHealthChecker registers the dependencies /readyz and /livez report on.
+// rdb is nil when Redis is disabled.
+func newHealthChecker(gormDB *gorm.DB, rdb *redis.Client, gameManager *game.GameManager) *health.Checker {
+	checker := health.NewChecker()
+
+	checker.AddReadiness(health.Check{
+		Name: "postgres",
+		Run: func(ctx context.Context) error {
+			sqlDB, err := gormDB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	})
+	checker.AddReadiness(health.Check{
+		Name:     "redis",
+		Disabled: rdb == nil,
+		Run: func(ctx context.Context) error {
+			return rdb.Ping(ctx).Err()
+		},
+	})
+	// RapidAPI 요청 한도를 아끼기 위해 결과를 잠시 재사용
+	checker.AddReadiness(health.Check{
+		Name:     "judge0",
+		CacheFor: 30 * time.Second,
+		Run: func(ctx context.Context) error {
+			return client.J0Client.Ping(ctx)
+		},
+	})
+
+	// 게임 루프가 멈추면 재시작 말고는 복구할 방법이 없으므로 liveness 에도 포함
+	gameLoop := health.Check{
+		Name: "gameManager",
+		Run: func(ctx context.Context) error {
+			if !gameManager.IsRunning() {
+				return errors.New("game loop is not running")
+			}
+			return nil
+		},
+	}
+	checker.AddReadiness(gameLoop)
+	checker.AddLiveness(gameLoop)
+
+	return checker
+}
+
+// migrateAndSeed brings the schema up to date and creates the default roles
+// and starter problems.
+func migrateAndSeed(gormDB *gorm.DB, repository repositories.Repository, logger *zap.SugaredLogger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	migrator, err := db.NewMigrator(gormDB)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range applied {
+		logger.Infow("migration applied", "version", m.Version, "name", m.Name)
+	}
+
+	result, err := db.Seed(ctx, repository)
+	if err != nil {
+		return err
+	}
+	if result.Problems > 0 {
+		logger.Infow("starter problems created", "count", result.Problems)
 	}
+	return nil
 }
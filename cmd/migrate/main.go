@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/db"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down [n]    revert the last n migrations (default 1)
+  status      list migrations and when they were applied
+  seed        create default roles and starter problems
+
+The database is configured like the API: -config or $CONFIG_FILE, then the
+DB_* environment variables.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	timeout := flag.Duration("timeout", 5*time.Minute, "give up after this long")
+	configPath := flag.String("config", "", "YAML or TOML config file (default $CONFIG_FILE)")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configPath, nil)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	conn, err := db.New(cfg.DbConfig)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+	defer func() {
+		sqlDB, err := conn.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if len(applied) == 0 {
+			fmt.Println("already up to date")
+		}
+
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			steps, err = strconv.Atoi(flag.Arg(1))
+			if err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps: %s", flag.Arg(1))
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nothing to revert")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, applied)
+		}
+
+	case "seed":
+		result, err := db.Seed(ctx, repositories.NewRepository(conn))
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		fmt.Printf("seeded roles, %d new problem(s)\n", result.Problems)
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
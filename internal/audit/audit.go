@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 기록하는 작업 이름
+const (
+	ActionSignIn           = "auth.signin"
+	ActionSignInTwoFactor  = "auth.signin.2fa"
+	ActionSignInOAuth      = "auth.signin.oauth"
+	ActionLogout           = "auth.logout"
+	ActionLockout          = "auth.lockout"
+	ActionPasswordChange   = "auth.password.change"
+	ActionPasswordReset    = "auth.password.reset"
+	ActionTwoFactorEnable  = "auth.2fa.enable"
+	ActionTwoFactorDisable = "auth.2fa.disable"
+	ActionAccountDeletion  = "user.deletion.schedule"
+	ActionRoleChange       = "user.role.change"
+	ActionAPIKeyCreate     = "apikey.create"
+	ActionAPIKeyDelete     = "apikey.delete"
+	ActionRoomKick         = "room.kick"
+	ActionRoomBan          = "room.ban"
+	ActionRoomClose        = "room.close"
+	ActionAdminAuditRead   = "admin.audit.read"
+	ActionUserCreate       = "user.create"
+	ActionSessionsRevoke   = "user.sessions.revoke"
+	ActionProblemImport    = "problem.import"
+	ActionProblemCreate    = "problem.create"
+	ActionProblemUpdate    = "problem.update"
+	ActionProblemPublish   = "problem.publish"
+	ActionProblemUnpublish = "problem.unpublish"
+	ActionRatingsRecompute = "ratings.recompute"
+)
+
+// Event is one audited action.
+type Event struct {
+	Action     string
+	ActorID    *uint
+	TargetType string
+	TargetID   string
+	Success    bool
+	IP         string
+	UserAgent  string
+	Metadata   map[string]interface{}
+	At         time.Time
+}
+
+// Recorder records audit events. Recording never fails the request; events
+// that cannot be stored are logged.
+type Recorder interface {
+	Record(Event)
+}
+
+// FromRequest starts a successful event for the request, with the signed-in
+// user (if any) as the actor.
+func FromRequest(c *gin.Context, action string) Event {
+	e := Event{
+		Action:    action,
+		Success:   true,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		At:        time.Now(),
+	}
+	if data, ok := c.Get("user"); ok {
+		if user, ok := data.(*mapper.MappedUser); ok && user != nil {
+			e.ActorID = &user.ID
+		}
+	}
+	if data, ok := c.Get("api_key"); ok {
+		if key, ok := data.(*models.APIKey); ok && key != nil {
+			e = e.With("api_key_id", key.ID)
+		}
+	}
+	return e
+}
+
+// WithActor sets the actor, for actions where the user is only known after
+// the handler ran (e.g. sign-in).
+func (e Event) WithActor(userID uint) Event {
+	e.ActorID = &userID
+	return e
+}
+
+// WithTarget sets what the action was applied to.
+func (e Event) WithTarget(targetType string, id interface{}) Event {
+	e.TargetType = targetType
+	e.TargetID = fmt.Sprint(id)
+	return e
+}
+
+// Failed marks the event as a failed attempt.
+func (e Event) Failed(reason string) Event {
+	e.Success = false
+	return e.With("reason", reason)
+}
+
+// With adds a metadata entry.
+func (e Event) With(key string, value interface{}) Event {
+	metadata := make(map[string]interface{}, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	e.Metadata = metadata
+	return e
+}
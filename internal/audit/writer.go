@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// 한 번에 저장하는 최대 이벤트 수
+	batchSize     = 100
+	flushInterval = time.Second
+	writeTimeout  = 5 * time.Second
+)
+
+// Writer stores events in Postgres in the background. Record only queues the
+// event; when the buffer is full the event is dropped and logged rather than
+// slowing down the request.
+type Writer struct {
+	repository repositories.AuditLogRepositoryInterface
+	events     chan models.AuditLog
+	done       chan struct{}
+	closeOnce  sync.Once
+	logger     *zap.SugaredLogger
+}
+
+func NewWriter(repository repositories.AuditLogRepositoryInterface, bufferSize int, logger *zap.SugaredLogger) *Writer {
+	return &Writer{
+		repository: repository,
+		events:     make(chan models.AuditLog, bufferSize),
+		done:       make(chan struct{}),
+		logger:     logger,
+	}
+}
+
+// Record queues an event. It is safe to call on a nil Writer.
+func (w *Writer) Record(e Event) {
+	if w == nil {
+		return
+	}
+
+	log := toAuditLog(e)
+	select {
+	case w.events <- log:
+	default:
+		w.logger.Warnw("audit buffer full, dropping event", "action", log.Action, "actorID", log.ActorID, "metadata", log.Metadata)
+	}
+}
+
+// Run writes queued events in batches until Close is called.
+func (w *Writer) Run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.AuditLog, 0, batchSize)
+	for {
+		select {
+		case log, ok := <-w.events:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, log)
+			if len(batch) >= batchSize {
+				batch = w.flush(batch)
+			}
+		case <-ticker.C:
+			batch = w.flush(batch)
+		}
+	}
+}
+
+// Close stops accepting events and waits until the queued ones are written
+// or ctx is done. Record must not be called after Close.
+func (w *Writer) Close(ctx context.Context) error {
+	w.closeOnce.Do(func() { close(w.events) })
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Writer) flush(batch []models.AuditLog) []models.AuditLog {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	if err := w.repository.CreateBatch(ctx, batch); err != nil {
+		// 저장 실패 시 로그로라도 남김
+		for _, log := range batch {
+			w.logger.Errorw("failed to store audit event", "action", log.Action, "actorID", log.ActorID, "success", log.Success, "metadata", log.Metadata, "error", err)
+		}
+	}
+	return batch[:0]
+}
+
+func toAuditLog(e Event) models.AuditLog {
+	metadata := "{}"
+	if len(e.Metadata) > 0 {
+		if b, err := json.Marshal(e.Metadata); err == nil {
+			metadata = string(b)
+		}
+	}
+
+	at := e.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	userAgent := e.UserAgent
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+
+	return models.AuditLog{
+		Action:     e.Action,
+		ActorID:    e.ActorID,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Success:    e.Success,
+		IP:         e.IP,
+		UserAgent:  userAgent,
+		Metadata:   metadata,
+		CreatedAt:  at,
+	}
+}
@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"go.uber.org/zap"
+)
+
+// fakeAuditLogRepository 는 저장된 배치를 기록. block 이 있으면 닫힐 때까지 저장을 멈춤
+type fakeAuditLogRepository struct {
+	repositories.AuditLogRepositoryInterface
+	mu      sync.Mutex
+	batches [][]models.AuditLog
+	err     error
+	block   chan struct{}
+}
+
+func (r *fakeAuditLogRepository) CreateBatch(_ context.Context, logs []models.AuditLog) error {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Writer 는 배치 슬라이스를 재사용하므로 복사해서 보관
+	r.batches = append(r.batches, append([]models.AuditLog(nil), logs...))
+	return r.err
+}
+
+func (r *fakeAuditLogRepository) actions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var actions []string
+	for _, batch := range r.batches {
+		for _, log := range batch {
+			actions = append(actions, log.Action)
+		}
+	}
+	return actions
+}
+
+func (r *fakeAuditLogRepository) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sizes []int
+	for _, batch := range r.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func closeWriter(t *testing.T, w *Writer) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+}
+
+func TestWriterFlushesOnClose(t *testing.T) {
+	tests := []struct {
+		name       string
+		bufferSize int
+		events     int
+		// Run 을 시작하기 전에 기록하면 버퍼에만 쌓임
+		beforeRun   bool
+		wantActions int
+		wantBatches []int
+	}{
+		{"nothing recorded", 10, 0, false, 0, nil},
+		{"queued before flush interval", 10, 3, false, 3, []int{3}},
+		{"full buffer drops the rest", 3, 5, true, 3, []int{3}},
+		{"split into batches", 250, 250, true, 250, []int{batchSize, batchSize, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAuditLogRepository{}
+			w := NewWriter(repo, tt.bufferSize, zap.NewNop().Sugar())
+
+			if !tt.beforeRun {
+				go w.Run()
+			}
+			for i := 0; i < tt.events; i++ {
+				w.Record(Event{Action: fmt.Sprintf("test.%d", i)})
+			}
+			if tt.beforeRun {
+				go w.Run()
+			}
+			closeWriter(t, w)
+
+			actions := repo.actions()
+			if len(actions) != tt.wantActions {
+				t.Fatalf("stored %d events, want %d", len(actions), tt.wantActions)
+			}
+			// 순서 유지
+			for i, action := range actions {
+				if want := fmt.Sprintf("test.%d", i); action != want {
+					t.Fatalf("event %d = %s, want %s", i, action, want)
+				}
+			}
+			if got := repo.batchSizes(); fmt.Sprint(got) != fmt.Sprint(tt.wantBatches) {
+				t.Errorf("batches = %v, want %v", got, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func TestWriterFlushesOnInterval(t *testing.T) {
+	repo := &fakeAuditLogRepository{}
+	w := NewWriter(repo, 10, zap.NewNop().Sugar())
+	go w.Run()
+	defer closeWriter(t, w)
+
+	w.Record(Event{Action: ActionSignIn})
+
+	deadline := time.Now().Add(3 * flushInterval)
+	for len(repo.actions()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("event was not written within the flush interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 저장이 끝나지 않으면 Close 는 ctx 만큼만 기다림
+func TestWriterCloseTimeout(t *testing.T) {
+	repo := &fakeAuditLogRepository{block: make(chan struct{})}
+	w := NewWriter(repo, 10, zap.NewNop().Sugar())
+	go w.Run()
+	w.Record(Event{Action: ActionSignIn})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := w.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(repo.block)
+	closeWriter(t, w)
+	if got := repo.actions(); len(got) != 1 {
+		t.Errorf("stored %v after the store recovered, want the queued event", got)
+	}
+}
+
+// 저장에 실패해도 Writer 는 멈추지 않음
+func TestWriterStoreError(t *testing.T) {
+	repo := &fakeAuditLogRepository{err: errors.New("connection refused")}
+	w := NewWriter(repo, 10, zap.NewNop().Sugar())
+	go w.Run()
+
+	w.Record(Event{Action: ActionSignIn})
+	w.Record(Event{Action: ActionLogout})
+	closeWriter(t, w)
+
+	if got := repo.actions(); len(got) != 2 {
+		t.Errorf("attempted to store %v, want both events", got)
+	}
+}
+
+func TestNilWriterRecord(t *testing.T) {
+	var w *Writer
+	w.Record(Event{Action: ActionSignIn})
+}
+
+func TestToAuditLog(t *testing.T) {
+	actor := uint(7)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		event        Event
+		wantMetadata string
+		wantAgentLen int
+	}{
+		{"no metadata", Event{Action: ActionSignIn, At: at}, "{}", 0},
+		{"metadata", Event{Action: ActionSignIn, At: at}.Failed("locked").With("email", "racer@example.com"),
+			`{"email":"racer@example.com","reason":"locked"}`, 0},
+		{"long user agent", Event{Action: ActionSignIn, At: at, UserAgent: strings.Repeat("a", 600)}, "{}", 512},
+		{"actor", Event{Action: ActionSignIn, At: at}.WithActor(actor).WithTarget("user", 9), "{}", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := toAuditLog(tt.event)
+			if log.Metadata != tt.wantMetadata {
+				t.Errorf("metadata = %s, want %s", log.Metadata, tt.wantMetadata)
+			}
+			if len(log.UserAgent) != tt.wantAgentLen {
+				t.Errorf("user agent length = %d, want %d", len(log.UserAgent), tt.wantAgentLen)
+			}
+			if !log.CreatedAt.Equal(at) || log.Success != tt.event.Success {
+				t.Errorf("log = %+v", log)
+			}
+			if tt.event.ActorID != nil && (log.ActorID == nil || *log.ActorID != actor || log.TargetID != "9") {
+				t.Errorf("actor/target = %v/%s", log.ActorID, log.TargetID)
+			}
+		})
+	}
+}
@@ -7,41 +7,77 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/config"
-	"github.com/Dongmoon29/code_racer_api/internal/mapper"
 	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
+	adminController "github.com/Dongmoon29/code_racer_api/internal/controllers/admin"
+	apiKeysController "github.com/Dongmoon29/code_racer_api/internal/controllers/apikeys"
 	authController "github.com/Dongmoon29/code_racer_api/internal/controllers/auth"
 	gameController "github.com/Dongmoon29/code_racer_api/internal/controllers/game"
+	healthController "github.com/Dongmoon29/code_racer_api/internal/controllers/health"
 	judge0Controller "github.com/Dongmoon29/code_racer_api/internal/controllers/judge0"
+	practiceController "github.com/Dongmoon29/code_racer_api/internal/controllers/practice"
+	problemsController "github.com/Dongmoon29/code_racer_api/internal/controllers/problems"
+	usersController "github.com/Dongmoon29/code_racer_api/internal/controllers/users"
 
+	adminService "github.com/Dongmoon29/code_racer_api/internal/services/admin"
+	apiKeysService "github.com/Dongmoon29/code_racer_api/internal/services/apikeys"
 	authService "github.com/Dongmoon29/code_racer_api/internal/services/auth"
 	gameService "github.com/Dongmoon29/code_racer_api/internal/services/game"
+	judgeService "github.com/Dongmoon29/code_racer_api/internal/services/judge"
 	judge0Service "github.com/Dongmoon29/code_racer_api/internal/services/judge0"
+	languagesService "github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	practiceService "github.com/Dongmoon29/code_racer_api/internal/services/practice"
+	problemsService "github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	submissionsService "github.com/Dongmoon29/code_racer_api/internal/services/submissions"
+	usersService "github.com/Dongmoon29/code_racer_api/internal/services/users"
 )
 
 const apiVersion = "v1"
 
 func Mount(app *config.Application) *gin.Engine {
 	r := gin.Default()
+	// X-Forwarded-For 는 설정된 프록시가 보낸 경우에만 신뢰 (IP 별 제한, 잠금, 감사 로그)
+	if err := r.SetTrustedProxies(app.Config.TrustedProxies); err != nil {
+		app.Logger.Errorw("invalid trusted proxies, trusting none", "error", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+	if app.Config.Tracing.Enabled() {
+		// 다른 미들웨어와 핸들러가 요청 span 안에서 실행되도록 가장 먼저 등록
+		r.Use(otelgin.Middleware(app.Config.Tracing.ServiceName))
+	}
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"DELETE", "POST", "GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowOrigins:     app.Config.CORS.AllowedOrigins,
+		AllowMethods:     []string{"DELETE", "POST", "GET", "PATCH", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-API-Key"},
 		ExposeHeaders:    []string{"Content-Length", "Set-Cookie"},
 		AllowCredentials: true,
 	}))
+	if app.Config.Metrics.Enabled {
+		r.Use(middlewares.MetricsMiddleware())
+	}
+
+	setHealthRoutes(app, r)
+	setMetricsRoutes(app, r)
 
 	apiGroup := r.Group(fmt.Sprintf("/api/%s", apiVersion))
 
 	setJudge0Routes(app, apiGroup)
 	setGameRoutes(app, apiGroup)
 	setUserRoutes(app, apiGroup)
+	setProblemRoutes(app, apiGroup)
+	setPracticeRoutes(app, apiGroup)
+	setAdminRoutes(app, apiGroup)
 	return r
 }
 
@@ -62,11 +98,19 @@ func Run(app *config.Application, router *gin.Engine) error {
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		s := <-quit
 
+		app.Logger.Infow("signal caught", "signal", s.String())
+
+		// 새 요청이 들어오지 않도록 readyz 를 먼저 실패시키고, 로드밸런서가
+		// 알아챌 시간을 준 뒤 종료
+		app.Health.Drain()
+		if delay := app.Config.ShutdownDelay; delay > 0 {
+			app.Logger.Infow("draining before shutdown", "delay", delay)
+			time.Sleep(delay)
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		app.Logger.Infow("signal caught", "signal", s.String())
-
 		shutdown <- srv.Shutdown(ctx)
 	}()
 
@@ -85,75 +129,302 @@ func Run(app *config.Application, router *gin.Engine) error {
 	return nil
 }
 
+// setHealthRoutes mounts the probes at the root, outside /api/v1, so they
+// never need auth and do not change with the API version.
+func setHealthRoutes(app *config.Application, r *gin.Engine) {
+	if app.Health == nil {
+		return
+	}
+	hc := healthController.NewHealthController(app.Health, app.Logger)
+	r.GET("/healthz", hc.HandleHealthz)
+	r.GET("/livez", hc.HandleLivez)
+	r.GET("/readyz", hc.HandleReadyz)
+}
+
+func setMetricsRoutes(app *config.Application, r *gin.Engine) {
+	if !app.Config.Metrics.Enabled {
+		return
+	}
+	r.GET("/metrics", middlewares.MetricsAuthMiddleware(app.Config.Metrics.Token), gin.WrapH(promhttp.Handler()))
+}
+
 func setUserRoutes(app *config.Application, rg *gin.RouterGroup) {
-	us := authService.NewAuthService(app.Repository.UserRepository, app.Repository.RoleRepository, app.CacheStorage.Users, app.Logger)
-	uc := authController.NewAuthController(us, app.Logger)
+	us := authService.NewAuthService(
+		app.Repository.UserRepository,
+		app.Repository.RoleRepository,
+		app.Repository.SessionRepository,
+		app.Repository.TwoFactorRepository,
+		userStoreFor(app),
+		sessionStoreFor(app),
+		app.CacheStorage.RateLimits,
+		app.CacheStorage.Tickets,
+		app.CacheStorage.LoginAttempts,
+		app.Audit,
+		oauthProvidersFor(app),
+		app.Mailer,
+		app.Config.PublicURL,
+		app.Config.AppURL,
+		app.Logger,
+	)
+	uc := authController.NewAuthController(us, app.Config.Cookie, app.Audit, app.Logger)
+
+	ps := usersService.NewUsersService(
+		app.Repository.UserRepository,
+		app.Repository.SubmissionRepository,
+		app.Repository.MatchRepository,
+		languagesService.NewLanguagesService(languageStoreFor(app), app.Config.Languages.CacheTTL, app.Logger),
+		userStoreFor(app),
+		app.Logger,
+	)
+	pc := usersController.NewUsersController(ps, app.Logger)
+	kc := apiKeysController.NewAPIKeysController(apiKeysService.NewAPIKeysService(app.Repository.APIKeyRepository, app.Logger), app.Audit, app.Logger)
 
 	cg := rg.Group("/users")
 	{
 		cg.POST("/signin", uc.HandleSignin)
 		cg.POST("/signup", uc.HandleSignup)
+		cg.POST("/refresh", uc.HandleRefresh)
+		cg.GET("/verify", uc.HandleVerifyEmail)
+		cg.POST("/verify/resend", middlewares.RateLimitMiddleware(app, "users.verify.resend"), uc.HandleResendVerification)
+		cg.POST("/password/forgot", middlewares.RateLimitMiddleware(app, "users.password.forgot"), uc.HandleForgotPassword)
+		cg.POST("/password/reset", middlewares.RateLimitMiddleware(app, "users.password.reset"), uc.HandleResetPassword)
+		cg.POST("/signin/2fa", middlewares.RateLimitMiddleware(app, "users.signin.2fa"), uc.HandleSigninTwoFactor)
+		cg.GET("/oauth/:provider", uc.HandleOAuthStart)
+		cg.GET("/oauth/:provider/callback", uc.HandleOAuthCallback)
 
 		cg.POST("/logout", middlewares.AuthMiddleware(app), uc.HandleLogout)
 		cg.GET("/profile", middlewares.AuthMiddleware(app), uc.HandleUserProfile)
+		cg.PATCH("/profile", middlewares.AuthMiddleware(app), pc.HandleUpdateProfile)
+		cg.POST("/password/change", middlewares.AuthMiddleware(app), middlewares.RateLimitMiddleware(app, "users.password.change"), uc.HandleChangePassword)
+		cg.POST("/2fa/enroll", middlewares.AuthMiddleware(app), uc.HandleEnrollTOTP)
+		cg.POST("/2fa/confirm", middlewares.AuthMiddleware(app), uc.HandleConfirmTOTP)
+		cg.POST("/2fa/disable", middlewares.AuthMiddleware(app), middlewares.RateLimitMiddleware(app, "users.2fa.disable"), uc.HandleDisableTOTP)
+		cg.POST("/2fa/recovery-codes", middlewares.AuthMiddleware(app), middlewares.RateLimitMiddleware(app, "users.2fa.recovery_codes"), uc.HandleRegenerateRecoveryCodes)
+		cg.DELETE("/account", middlewares.AuthMiddleware(app), middlewares.RateLimitMiddleware(app, "users.account.delete"), uc.HandleDeleteAccount)
+		cg.GET("/export", middlewares.AuthMiddleware(app), middlewares.RateLimitMiddleware(app, "users.export"), pc.HandleExport)
+
+		cg.GET("/api-keys", middlewares.AuthMiddleware(app), kc.HandleListKeys)
+		cg.POST("/api-keys", middlewares.AuthMiddleware(app), kc.HandleCreateKey)
+		cg.DELETE("/api-keys/:id", middlewares.AuthMiddleware(app), kc.HandleDeleteKey)
+
+		// 위 고정 경로를 추가하면 utils/auth/username.go 의 예약어에도 추가
+		cg.GET("/:username", pc.HandleGetPublicProfile)
 	}
 }
 
+// oauthProvidersFor builds the enabled OAuth providers. Their callback is
+// served by this API under /users/oauth/:provider/callback.
+func oauthProvidersFor(app *config.Application) map[string]authService.OAuthProvider {
+	providers := make(map[string]authService.OAuthProvider)
+	for name, pc := range app.Config.OAuthProviders {
+		if pc.ClientID == "" {
+			continue
+		}
+
+		redirectURL := fmt.Sprintf("%s/api/%s/users/oauth/%s/callback", strings.TrimRight(app.Config.PublicURL, "/"), apiVersion, name)
+		provider, err := authService.NewOAuthProvider(name, pc.ClientID, pc.ClientSecret, pc.AuthURL, pc.TokenURL, pc.UserInfoURL, redirectURL, pc.Scopes)
+		if err != nil {
+			app.Logger.Errorw("skipping oauth provider", "provider", name, "error", err)
+			continue
+		}
+		providers[name] = provider
+	}
+	return providers
+}
+
 func setGameRoutes(app *config.Application, rg *gin.RouterGroup) {
-	gs := gameService.NewGameService(app.GameManager, app.CacheStorage.Games, app.Logger)
-	gc := gameController.NewGameController(gs, app.Logger)
+	gs := gameService.NewGameService(app.GameManager, app.CacheStorage.Games, app.CacheStorage.Tickets, app.Logger)
+	gc := gameController.NewGameController(gs, app.Config.CORS.AllowedOrigins, app.Audit, app.Logger)
 
 	gg := rg.Group("/games")
-	gg.Use(middlewares.AuthMiddleware(app))
-	{
-		gg.GET("", gc.HandleGetGameRooms)
-		gg.GET("/ws", gc.HandleGameWebSocket)
-		gg.GET("/status", gc.HandleGetGameManagerStatus)
+	// 웹소켓은 업그레이드 전에 쿠키, Bearer 토큰 또는 티켓으로 인증
+	gg.GET("/ws", middlewares.WebSocketAuthMiddleware(app), gc.HandleGameWebSocket)
+
+	gg.GET("", middlewares.AuthMiddleware(app, models.ScopeGamesRead), gc.HandleGetGameRooms)
+	gg.POST("/ws-ticket", middlewares.AuthMiddleware(app), gc.HandleIssueWebSocketTicket)
+	gg.GET("/status",
+		middlewares.AuthMiddleware(app, models.ScopeGamesRead),
+		middlewares.RequirePermission(app, models.PermissionGameStatusRead),
+		gc.HandleGetGameManagerStatus,
+	)
+	gg.POST("/rooms/:id/kick",
+		middlewares.AuthMiddleware(app),
+		middlewares.RequirePermission(app, models.PermissionRoomModerate),
+		gc.HandleRemovePlayer,
+	)
+
+	// 운영자용 방 관리 (cmd/admin에서 사용)
+	rg.GET("/admin/rooms",
+		middlewares.AuthMiddleware(app),
+		middlewares.RequirePermission(app, models.PermissionGameStatusRead),
+		gc.HandleListRooms,
+	)
+	rg.DELETE("/admin/rooms/:id",
+		middlewares.AuthMiddleware(app),
+		middlewares.RequirePermission(app, models.PermissionRoomModerate),
+		gc.HandleCloseRoom,
+	)
+}
 
+func setAdminRoutes(app *config.Application, rg *gin.RouterGroup) {
+	as := adminService.NewAdminService(
+		app.Repository.UserRepository,
+		app.Repository.RoleRepository,
+		app.Repository.AuditLogRepository,
+		app.Repository.ProblemRepository,
+		app.Repository.MatchRepository,
+		userStoreFor(app),
+		app.Logger,
+	)
+	ac := adminController.NewAdminController(as, app.Audit, app.Logger)
+
+	ag := rg.Group("/admin")
+	ag.Use(middlewares.AuthMiddleware(app))
+	{
+		ag.GET("/audit-logs", middlewares.RequirePermission(app, models.PermissionAuditRead), ac.HandleListAuditLogs)
+		ag.PATCH("/users/:id/role", middlewares.RequirePermission(app, models.PermissionUserManage), ac.HandleChangeUserRole)
 	}
 }
 
 func setJudge0Routes(app *config.Application, rg *gin.RouterGroup) {
+	ls := languagesService.NewLanguagesService(languageStoreFor(app), app.Config.Languages.CacheTTL, app.Logger)
 	js := judge0Service.NewJudge0Service(app.Logger)
-	jc := judge0Controller.NewJudge0Controller(js, app.Logger)
+	jc := judge0Controller.NewJudge0Controller(js, ls, newSubmissionsService(app), app.Logger)
+
+	// API 키는 라우트별 범위로 허용
+	readAuth := middlewares.AuthMiddleware(app, models.ScopeCodeSubmit, models.ScopeProblemsRead)
+	submitAuth := middlewares.AuthMiddleware(app, models.ScopeCodeSubmit)
+	submissionsAuth := middlewares.AuthMiddleware(app, models.ScopeSubmissionsRead)
 
 	jg := rg.Group("/code")
-	jg.Use(middlewares.AuthMiddleware(app))
 	{
-		jg.GET("/about", jc.GetAbout)
-		jg.POST("/submit", jc.HandleCreateCodeSubmission)
+		jg.GET("/about", readAuth, jc.GetAbout)
+		jg.GET("/languages", readAuth, jc.HandleGetLanguages)
+		jg.POST("/submit",
+			submitAuth,
+			middlewares.RateLimitMiddleware(app, "code.submit"),
+			middlewares.DailyQuotaMiddleware(app, "code.submit", app.Config.SubmissionQuotas),
+			jc.HandleCreateCodeSubmission,
+		)
+		jg.POST("/submissions",
+			submitAuth,
+			middlewares.RateLimitMiddleware(app, "code.submit"),
+			middlewares.DailyQuotaMiddleware(app, "code.submit", app.Config.SubmissionQuotas),
+			jc.HandleSubmit,
+		)
+		jg.GET("/submissions", submissionsAuth, jc.HandleGetSubmissions)
+		jg.GET("/submissions/:id", submissionsAuth, jc.HandleGetSubmission)
 	}
 }
 
-func GetUser(app *config.Application, ctx context.Context, userID int) (*mapper.MappedUser, error) {
-	if !app.Config.RedisConfig.Enabled {
-		return getUserFromDB(app, ctx, userID)
+func setProblemRoutes(app *config.Application, rg *gin.RouterGroup) {
+	ps := newProblemsService(app)
+	pc := problemsController.NewProblemsController(ps, app.Audit, app.Logger)
+
+	pg := rg.Group("/problems")
+	pg.Use(middlewares.AuthMiddleware(app, models.ScopeProblemsRead))
+	{
+		pg.GET("", pc.HandleGetProblems)
+		pg.GET("/:id", pc.HandleGetProblem)
+		pg.GET("/:id/stats", pc.HandleGetProblemStats)
 	}
 
-	user, err := app.CacheStorage.Users.Get(ctx, userID)
-	if err != nil {
-		return nil, err
+	// 문제 출제 (공개 여부와 무관하게 전체 내용을 다룸)
+	ag := rg.Group("/admin/problems")
+	ag.Use(middlewares.AuthMiddleware(app), middlewares.RequirePermission(app, models.PermissionProblemManage))
+	{
+		ag.GET("", pc.HandleListAllProblems)
+		ag.POST("", pc.HandleCreateProblem)
+		ag.POST("/import", pc.HandleImportProblem)
+		ag.GET("/:id", pc.HandleGetProblemForEdit)
+		ag.PUT("/:id", pc.HandleUpdateProblem)
+		ag.POST("/:id/publish", pc.HandlePublishProblem)
+		ag.POST("/:id/unpublish", pc.HandleUnpublishProblem)
+		ag.POST("/:id/validate", pc.HandleValidateProblem)
+		ag.GET("/:id/export", pc.HandleExportProblem)
+		ag.GET("/:id/versions", pc.HandleListProblemVersions)
+		ag.GET("/:id/versions/:version", pc.HandleGetProblemVersion)
 	}
+}
 
-	if user == nil {
-		user, err := app.Repository.UserRepository.GetByID(ctx, userID)
-		if err != nil {
-			return nil, err
-		}
+func setPracticeRoutes(app *config.Application, rg *gin.RouterGroup) {
+	ls := languagesService.NewLanguagesService(languageStoreFor(app), app.Config.Languages.CacheTTL, app.Logger)
+	js := judge0Service.NewJudge0Service(app.Logger)
+	jds := judgeService.NewJudgeService(js, ls, app.Logger)
+	ps := practiceService.NewPracticeService(
+		app.Repository.PracticeRepository,
+		newProblemsService(app),
+		newSubmissionsService(app),
+		jds,
+		ls,
+		app.Logger,
+	)
+	pc := practiceController.NewPracticeController(ps, app.Logger)
+
+	submitAuth := middlewares.AuthMiddleware(app, models.ScopeCodeSubmit)
+
+	pg := rg.Group("/practice")
+	{
+		pg.POST("", submitAuth, pc.HandleStartPractice)
+		pg.GET("/bests", middlewares.AuthMiddleware(app, models.ScopeSubmissionsRead), pc.HandleGetPersonalBests)
+		pg.POST("/:id/run", submitAuth, middlewares.RateLimitMiddleware(app, "code.submit"), pc.HandleRunSamples)
+		pg.POST("/:id/submit",
+			submitAuth,
+			middlewares.RateLimitMiddleware(app, "code.submit"),
+			middlewares.DailyQuotaMiddleware(app, "code.submit", app.Config.SubmissionQuotas),
+			pc.HandleSubmit,
+		)
+	}
+}
 
-		mappedUser := mapper.UserMapper(user)
-		if err := app.CacheStorage.Users.Set(ctx, mappedUser); err != nil {
-			return nil, err
-		}
+func newProblemsService(app *config.Application) problemsService.ProblemsService {
+	ls := languagesService.NewLanguagesService(languageStoreFor(app), app.Config.Languages.CacheTTL, app.Logger)
+	js := judge0Service.NewJudge0Service(app.Logger)
+	return problemsService.NewProblemsService(
+		app.Repository.ProblemRepository,
+		app.Repository.SubmissionRepository,
+		app.Repository.PracticeRepository,
+		ls,
+		judgeService.NewJudgeService(js, ls, app.Logger),
+		app.Logger,
+	)
+}
+
+func newSubmissionsService(app *config.Application) submissionsService.SubmissionsService {
+	ls := languagesService.NewLanguagesService(languageStoreFor(app), app.Config.Languages.CacheTTL, app.Logger)
+	js := judge0Service.NewJudge0Service(app.Logger)
+	return submissionsService.NewSubmissionsService(
+		app.Repository.SubmissionRepository,
+		app.Repository.ProblemRepository,
+		app.Repository.RoleRepository,
+		js,
+		judgeService.NewJudgeService(js, ls, app.Logger),
+		app.Logger,
+	)
+}
+
+// languageStoreFor returns the language catalog cache, or nil when Redis is
+// disabled so the languages service falls back to its in-process cache.
+func languageStoreFor(app *config.Application) cache.LanguagesRedisStoreInterface {
+	if !app.Config.RedisConfig.Enabled {
+		return nil
 	}
+	return app.CacheStorage.Languages
+}
 
-	return user, nil
+// userStoreFor returns the cached user store, or nil when Redis is disabled.
+func userStoreFor(app *config.Application) cache.UsersRedisStoreInterface {
+	if !app.Config.RedisConfig.Enabled {
+		return nil
+	}
+	return app.CacheStorage.Users
 }
 
-func getUserFromDB(app *config.Application, ctx context.Context, userID int) (*mapper.MappedUser, error) {
-	user, err := app.Repository.UserRepository.GetByID(ctx, userID)
-	if err != nil {
-		return nil, err
+// sessionStoreFor returns the session state cache, or nil when Redis is
+// disabled so sessions are always checked against Postgres.
+func sessionStoreFor(app *config.Application) cache.SessionRedisStoreInterface {
+	if !app.Config.RedisConfig.Enabled {
+		return nil
 	}
-	return mapper.UserMapper(user), nil
+	return app.CacheStorage.Sessions
 }
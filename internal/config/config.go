@@ -2,11 +2,16 @@ package config
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/health"
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
 	"github.com/Dongmoon29/code_racer_api/internal/services/game"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +21,9 @@ type Application struct {
 	Config       *Config
 	Logger       *zap.SugaredLogger
 	GameManager  *game.GameManager
+	Mailer       mailer.Mailer
+	Audit        *audit.Writer
+	Health       *health.Checker
 }
 
 type Config struct {
@@ -24,6 +32,55 @@ type Config struct {
 	Addr        string
 	Env         string
 	GameManager *game.GameManager
+	// RateLimits is keyed by the route name passed to RateLimitMiddleware.
+	RateLimits map[string]RouteRateLimit
+	// SubmissionQuotas is the number of code submissions allowed per day,
+	// keyed by role name. Roles without an entry are not limited.
+	SubmissionQuotas map[string]int
+	// PublicURL is the externally reachable base URL of the API, used to
+	// build links sent by email.
+	PublicURL string
+	// AppURL is the base URL of the web client, used for links that open a
+	// page there (e.g. password reset).
+	AppURL string
+	Mailer mailer.Config
+	// OAuthProviders is keyed by provider name ("github", "google"). Providers
+	// without a client id are disabled.
+	OAuthProviders map[string]OAuthProviderConfig
+	JWT            JWTConfig
+	Judge0         Judge0Config
+	Languages      LanguagesConfig
+	CORS           CORSConfig
+	Cookie         CookieConfig
+	Game           game.Limits
+	Metrics        MetricsConfig
+	Tracing        tracing.Config
+	// TrustedProxies lists the reverse proxies (IPs or CIDRs) whose
+	// X-Forwarded-For and X-Real-IP headers are believed when resolving the
+	// client IP. Empty trusts none and uses the connection's address.
+	TrustedProxies []string
+	// ShutdownDelay is how long the server keeps serving after SIGTERM with
+	// /readyz failing, so load balancers stop routing to it first.
+	ShutdownDelay time.Duration
+}
+
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+}
+
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+type RouteRateLimit struct {
+	PerUser RateLimit
+	PerIP   RateLimit
 }
 
 type RedisConfig struct {
@@ -43,6 +100,58 @@ type DbConfig struct {
 	MaxOpenConns int
 	MaxIdleConns int
 	MaxIdleTime  time.Duration
+	// AutoMigrate applies pending migrations and seeds when the API starts.
+	AutoMigrate bool
+}
+
+type JWTConfig struct {
+	// Secret signs access tokens and one-off action tokens.
+	Secret string
+}
+
+type Judge0Config struct {
+	BaseURL string
+	// Host and APIKey are sent as RapidAPI headers; leave both empty for a
+	// self-hosted Judge0.
+	Host    string
+	APIKey  string
+	Timeout time.Duration
+}
+
+type LanguagesConfig struct {
+	// CacheTTL is how long the Judge0 language catalog is cached. Unknown
+	// language ids refresh it earlier.
+	CacheTTL time.Duration
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string
+}
+
+type MetricsConfig struct {
+	Enabled bool
+	// Token, when set, is required as a bearer token to scrape /metrics.
+	Token string
+}
+
+// CookieConfig applies to the auth and OAuth state cookies.
+type CookieConfig struct {
+	Secure bool
+	Domain string
+	// SameSite is "lax", "strict" or "none".
+	SameSite string
+}
+
+// SameSiteMode returns the http.SameSite value for SameSite.
+func (c CookieConfig) SameSiteMode() http.SameSite {
+	switch c.SameSite {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
 }
 
 func (cfg *DbConfig) GetPostgresDsn() string {
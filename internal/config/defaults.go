@@ -0,0 +1,113 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
+	"github.com/Dongmoon29/code_racer_api/internal/services/game"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
+)
+
+const (
+	// 개발용 기본값: prod 에서는 Validate 가 거부함
+	defaultJWTSecret  = "secret"
+	defaultDbPassword = "password1234"
+)
+
+// Defaults returns the configuration used for anything that is not set in
+// the config file, the environment or on the command line. It is meant for
+// local development.
+func Defaults() *Config {
+	return &Config{
+		DbConfig: DbConfig{
+			Host:         "localhost",
+			User:         "postgres",
+			Password:     defaultDbPassword,
+			Dbname:       "code_racer_db",
+			Port:         5432,
+			Timezone:     "Asia/seoul",
+			MaxOpenConns: 10,
+			MaxIdleConns: 5,
+			MaxIdleTime:  15 * time.Minute,
+			AutoMigrate:  true,
+		},
+		RedisConfig: RedisConfig{
+			Enabled: true,
+			Addr:    "localhost:6379",
+		},
+		RateLimits: map[string]RouteRateLimit{
+			"code.submit": {
+				PerUser: RateLimit{Requests: 10, Per: time.Minute},
+				PerIP:   RateLimit{Requests: 30, Per: time.Minute},
+			},
+			"users.verify.resend": {
+				PerIP: RateLimit{Requests: 5, Per: time.Hour},
+			},
+			"users.password.forgot": {
+				PerIP: RateLimit{Requests: 5, Per: time.Hour},
+			},
+			"users.signin.2fa": {
+				PerIP: RateLimit{Requests: 20, Per: time.Minute},
+			},
+			"users.password.reset": {
+				PerIP: RateLimit{Requests: 10, Per: time.Hour},
+			},
+			"users.export": {
+				PerUser: RateLimit{Requests: 5, Per: time.Hour},
+			},
+			"users.password.change": {
+				PerUser: RateLimit{Requests: 10, Per: time.Hour},
+			},
+			"users.2fa.disable": {
+				PerUser: RateLimit{Requests: 10, Per: time.Hour},
+			},
+			"users.2fa.recovery_codes": {
+				PerUser: RateLimit{Requests: 10, Per: time.Hour},
+			},
+			"users.account.delete": {
+				PerUser: RateLimit{Requests: 10, Per: time.Hour},
+			},
+		},
+		SubmissionQuotas: map[string]int{
+			"user":      500,
+			"moderator": 2000,
+		},
+		PublicURL: "http://localhost:8080",
+		AppURL:    "http://localhost:3000",
+		Mailer: mailer.Config{
+			Driver: mailer.DriverLog,
+			Port:   587,
+			From:   "no-reply@localhost",
+		},
+		OAuthProviders: map[string]OAuthProviderConfig{
+			"github": {
+				AuthURL:     "https://github.com/login/oauth/authorize",
+				TokenURL:    "https://github.com/login/oauth/access_token",
+				UserInfoURL: "https://api.github.com/user",
+				Scopes:      []string{"read:user", "user:email"},
+			},
+			"google": {
+				AuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+				TokenURL:    "https://oauth2.googleapis.com/token",
+				UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+				Scopes:      []string{"openid", "email", "profile"},
+			},
+		},
+		JWT: JWTConfig{Secret: defaultJWTSecret},
+		Judge0: Judge0Config{
+			BaseURL: "https://judge0-ce.p.rapidapi.com",
+			Host:    "judge0-ce.p.rapidapi.com",
+			Timeout: 30 * time.Second,
+		},
+		Languages: LanguagesConfig{CacheTTL: time.Hour},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:3000"},
+		},
+		Cookie:  CookieConfig{SameSite: "lax"},
+		Metrics: MetricsConfig{Enabled: true},
+		Tracing: tracing.DefaultConfig,
+		Game:    game.DefaultLimits,
+		Addr:    ":8080",
+		Env:     "dev",
+	}
+}
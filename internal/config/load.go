@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load builds the configuration from, in increasing order of precedence,
+// Defaults, the config file at path, environment variables and overrides
+// ("key=value", keys as in the config file). An empty path falls back to
+// $CONFIG_FILE; with neither, no file is read. Callers run Validate on the
+// result; it is left to them so an invalid config can still be printed.
+func Load(path string, overrides []string) (*Config, error) {
+	cfg := Defaults()
+	all, commit := settings(cfg)
+	byKey := make(map[string]setting, len(all))
+	for _, s := range all {
+		byKey[s.key] = s
+	}
+
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		// 파일 안 순서와 무관하게 같은 에러가 나오도록 키 순으로 적용
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s, ok := byKey[key]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown setting %q", path, key)
+			}
+			if err := s.value.Set(values[key]); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", path, key, err)
+			}
+		}
+	}
+
+	for _, s := range all {
+		if s.env == "" {
+			continue
+		}
+		if v, ok := os.LookupEnv(s.env); ok {
+			if err := s.value.Set(v); err != nil {
+				return nil, fmt.Errorf("$%s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, o := range overrides {
+		key, v, ok := strings.Cut(o, "=")
+		if !ok {
+			return nil, fmt.Errorf("-set %q: expected key=value", o)
+		}
+		s, ok := byKey[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("-set: unknown setting %q", key)
+		}
+		if err := s.value.Set(v); err != nil {
+			return nil, fmt.Errorf("-set %s: %w", key, err)
+		}
+	}
+
+	commit()
+	return cfg, nil
+}
+
+// readFile reads a YAML (.yaml, .yml) or TOML (.toml) config file into
+// dotted keys. List values are joined with commas.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format %q, use .yaml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", doc, values)
+	return values, nil
+}
+
+func flatten(prefix string, doc map[string]interface{}, out map[string]string) {
+	for key, v := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			flatten(key, v, out)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// Print writes cfg as YAML in the config file format, with secrets
+// redacted, so the output can be used as a config file once they are
+// filled in.
+func Print(w io.Writer, cfg *Config) error {
+	all, _ := settings(cfg)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{"": root}
+	for _, s := range all {
+		parts := strings.Split(s.key, ".")
+		parent := root
+		for i := range parts[:len(parts)-1] {
+			name := strings.Join(parts[:i+1], ".")
+			section, ok := sections[name]
+			if !ok {
+				section = &yaml.Node{Kind: yaml.MappingNode}
+				parent.Content = append(parent.Content, scalar(parts[i]), section)
+				sections[name] = section
+			}
+			parent = section
+		}
+
+		var node *yaml.Node
+		switch v := s.value.(type) {
+		case listValue:
+			node = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+			for _, item := range *v.p {
+				node.Content = append(node.Content, scalar(item))
+			}
+		default:
+			text := v.String()
+			if s.secret && text != "" {
+				text = "[redacted]"
+			}
+			node = scalar(text)
+			if _, ok := v.(stringValue); ok {
+				node.Tag = "!!str"
+			}
+		}
+		parent.Content = append(parent.Content, scalar(parts[len(parts)-1]), node)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unsetEnv clears every environment variable Load reads for the duration of
+// the test, so the machine running it cannot change the result.
+func unsetEnv(t *testing.T) {
+	t.Helper()
+	all, _ := settings(Defaults())
+	envs := []string{"CONFIG_FILE"}
+	for _, s := range all {
+		if s.env != "" {
+			envs = append(envs, s.env)
+		}
+	}
+	for _, env := range envs {
+		if v, ok := os.LookupEnv(env); ok {
+			os.Unsetenv(env)
+			t.Cleanup(func() { os.Setenv(env, v) })
+		}
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := "judge0:\n  timeout: 20s\ngame:\n  max_rooms: 50\ncors:\n  allowed_origins: [https://a.example, https://b.example]\n"
+
+	tests := []struct {
+		name      string
+		file      bool
+		env       string
+		overrides []string
+		want      time.Duration
+	}{
+		{"default", false, "", nil, Defaults().Judge0.Timeout},
+		{"file over default", true, "", nil, 20 * time.Second},
+		{"env over default", false, "30s", nil, 30 * time.Second},
+		{"env over file", true, "30s", nil, 30 * time.Second},
+		{"flag over file", true, "", []string{"judge0.timeout=40s"}, 40 * time.Second},
+		{"flag over env", true, "30s", []string{"judge0.timeout=40s"}, 40 * time.Second},
+		{"last flag wins", false, "", []string{"judge0.timeout=40s", "judge0.timeout=50s"}, 50 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t)
+			path := ""
+			if tt.file {
+				path = writeFile(t, "config.yaml", file)
+			}
+			if tt.env != "" {
+				t.Setenv("JUDGE0_TIMEOUT", tt.env)
+			}
+
+			cfg, err := Load(path, tt.overrides)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Judge0.Timeout != tt.want {
+				t.Errorf("judge0.timeout = %s, want %s", cfg.Judge0.Timeout, tt.want)
+			}
+			// 다른 설정은 각자의 출처를 유지
+			if tt.file && cfg.Game.MaxRooms != 50 {
+				t.Errorf("game.max_rooms = %d, want 50 from the file", cfg.Game.MaxRooms)
+			}
+		})
+	}
+}
+
+func TestLoadFileFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"config.yaml", "jwt:\n  secret: from-file\noauth:\n  github:\n    client_id: gh\n    scopes: [read:user, user:email]\nrate_limits:\n  code_submit:\n    per_user: 3/1m\nsubmission_quotas:\n  user: 7\n"},
+		{"config.toml", "[jwt]\nsecret = \"from-file\"\n[oauth.github]\nclient_id = \"gh\"\nscopes = [\"read:user\", \"user:email\"]\n[rate_limits.code_submit]\nper_user = \"3/1m\"\n[submission_quotas]\nuser = 7\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t)
+			cfg, err := Load(writeFile(t, tt.name, tt.content), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.JWT.Secret != "from-file" {
+				t.Errorf("jwt.secret = %q", cfg.JWT.Secret)
+			}
+			// map 에 저장되는 설정도 반영되어야 함
+			if gh := cfg.OAuthProviders["github"]; gh.ClientID != "gh" || strings.Join(gh.Scopes, " ") != "read:user user:email" {
+				t.Errorf("oauth.github = %+v", gh)
+			}
+			if l := cfg.RateLimits["code.submit"].PerUser; l.Requests != 3 || l.Per != time.Minute {
+				t.Errorf("rate_limits.code_submit.per_user = %+v", l)
+			}
+			if q := cfg.SubmissionQuotas["user"]; q != 7 {
+				t.Errorf("submission_quotas.user = %d", q)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		env       map[string]string
+		overrides []string
+		wantErr   string
+	}{
+		{"bad duration in file", "judge0:\n  timeout: soon\n", nil, nil, "judge0.timeout"},
+		{"bad duration in env", "", map[string]string{"JUDGE0_TIMEOUT": "10"}, nil, "$JUDGE0_TIMEOUT"},
+		{"bad duration flag", "", nil, []string{"languages.cache_ttl=1 hour"}, "-set languages.cache_ttl"},
+		{"bad integer", "", map[string]string{"DB_PORT": "postgres"}, nil, "$DB_PORT"},
+		{"bad rate limit", "", nil, []string{"rate_limits.code_submit.per_ip=10/soon"}, "rate_limits.code_submit.per_ip"},
+		{"unknown file key", "jwt:\n  secrte: x\n", nil, nil, `unknown setting "jwt.secrte"`},
+		{"unknown flag key", "", nil, []string{"jwt.secrte=x"}, `unknown setting "jwt.secrte"`},
+		{"flag without value", "", nil, []string{"jwt.secret"}, "expected key=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t)
+			path := ""
+			if tt.file != "" {
+				path = writeFile(t, "config.yaml", tt.file)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := Load(path, tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadUnsupportedFormat(t *testing.T) {
+	unsetEnv(t)
+	if _, err := Load(writeFile(t, "config.json", "{}"), nil); err == nil {
+		t.Error("Load accepted a .json config file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []string
+		wantErr   []string
+	}{
+		{"defaults", nil, nil},
+		{"missing jwt secret", []string{"jwt.secret="}, []string{"jwt.secret must not be empty"}},
+		{"zero duration", []string{"judge0.timeout=0s"}, []string{"judge0.timeout must be positive"}},
+		{"negative duration", []string{"languages.cache_ttl=-1m", "shutdown_delay=-1s"}, []string{"languages.cache_ttl must be positive", "shutdown_delay must not be negative"}},
+		{"bad proxy", []string{"trusted_proxies=10.0.0.0/8,proxy.internal"}, []string{`trusted_proxies: "proxy.internal"`}},
+		{"rate limit without window", []string{"rate_limits.export.per_ip=5"}, []string{"rate_limits.export.per_ip needs a window"}},
+		{"prod defaults", []string{"env=prod"}, []string{
+			"jwt.secret must be a random string",
+			"db.password must not be the development default",
+			"cookie.secure must be true",
+			`mail.driver "log" does not deliver mail`,
+			"judge0.api_key is required for RapidAPI",
+			`cors.allowed_origins must not contain "http://localhost:3000"`,
+		}},
+		{"prod without origins", []string{"env=prod", "cors.allowed_origins="}, []string{"cors.allowed_origins must not be empty"}},
+		{"prod short secret", []string{
+			"env=prod", "jwt.secret=too-short", "db.password=x", "cookie.secure=true",
+			"public_url=https://api.example", "app_url=https://example", "mail.driver=smtp",
+			"cors.allowed_origins=https://example", "judge0.api_key=key",
+		}, []string{"jwt.secret must be a random string"}},
+		{"prod", []string{
+			"env=prod", "jwt.secret=" + strings.Repeat("k", minJWTSecretLength), "db.password=x", "cookie.secure=true",
+			"public_url=https://api.example", "app_url=https://example", "mail.driver=smtp",
+			"cors.allowed_origins=https://example", "judge0.api_key=key",
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t)
+			cfg, err := Load("", tt.overrides)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %q", tt.wantErr)
+			}
+			// 모든 문제를 한 번에 보고
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	unsetEnv(t)
+
+	all, _ := settings(Defaults())
+	var overrides, secrets []string
+	for i, s := range all {
+		if s.secret {
+			value := fmt.Sprintf("secret-value-%d", i)
+			overrides = append(overrides, s.key+"="+value)
+			secrets = append(secrets, value)
+		}
+	}
+	if len(secrets) == 0 {
+		t.Fatal("no secret settings")
+	}
+
+	cfg, err := Load("", overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JWT.Secret == "" || cfg.OAuthProviders["google"].ClientSecret == "" {
+		t.Fatal("secret overrides were not applied")
+	}
+
+	var buf bytes.Buffer
+	if err := Print(&buf, cfg); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, secret := range secrets {
+		if strings.Contains(out, secret) {
+			t.Errorf("Print output contains %q:\n%s", secret, out)
+		}
+	}
+	if got := strings.Count(out, "[redacted]"); got != len(secrets) {
+		t.Errorf("Print redacted %d values, want %d", got, len(secrets))
+	}
+}
+
+// 출력은 그대로 설정 파일로 쓸 수 있어야 함
+func TestPrintRoundTrip(t *testing.T) {
+	unsetEnv(t)
+	cfg, err := Load("", []string{"trusted_proxies=10.0.0.1,10.1.0.0/16", "game.max_rooms=7", "tracing.sample_ratio=0.25", "mail.smtp.username=123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var printed bytes.Buffer
+	if err := Print(&printed, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Load(writeFile(t, "config.yaml", printed.String()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again bytes.Buffer
+	if err := Print(&again, reloaded); err != nil {
+		t.Fatal(err)
+	}
+	if printed.String() != again.String() {
+		t.Errorf("printed config changed after reloading:\n%s\nvs\n%s", printed.String(), again.String())
+	}
+	if reloaded.Mailer.Username != "123" {
+		t.Errorf("mail.smtp.username = %q, want the string 123", reloaded.Mailer.Username)
+	}
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting is one configurable value. key is its name in the config file
+// (nested sections joined with dots) and in -set overrides; env is the
+// environment variable that overrides it, if any.
+type setting struct {
+	key    string
+	env    string
+	secret bool
+	value  value
+}
+
+type value interface {
+	Set(string) error
+	String() string
+}
+
+type stringValue struct{ p *string }
+
+func (v stringValue) Set(s string) error { *v.p = s; return nil }
+func (v stringValue) String() string     { return *v.p }
+
+type intValue struct{ p *int }
+
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not an integer", s)
+	}
+	*v.p = n
+	return nil
+}
+
+func (v intValue) String() string { return strconv.Itoa(*v.p) }
+
+type boolValue struct{ p *bool }
+
+func (v boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not a boolean", s)
+	}
+	*v.p = b
+	return nil
+}
+
+func (v boolValue) String() string { return strconv.FormatBool(*v.p) }
+
+type floatValue struct{ p *float64 }
+
+func (v floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", s)
+	}
+	*v.p = f
+	return nil
+}
+
+func (v floatValue) String() string { return strconv.FormatFloat(*v.p, 'g', -1, 64) }
+
+type durationValue struct{ p *time.Duration }
+
+func (v durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%q is not a duration", s)
+	}
+	*v.p = d
+	return nil
+}
+
+func (v durationValue) String() string { return v.p.String() }
+
+// listValue is a comma separated list; the config file may also use a
+// list.
+type listValue struct{ p *[]string }
+
+func (v listValue) Set(s string) error {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*v.p = items
+	return nil
+}
+
+func (v listValue) String() string { return strings.Join(*v.p, ",") }
+
+// rateLimitValue is "N" (keeping the window) or "N/window", e.g. "10/1m".
+type rateLimitValue struct{ p *RateLimit }
+
+func (v rateLimitValue) Set(s string) error {
+	requests, per, hasPer := strings.Cut(strings.TrimSpace(s), "/")
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 0 {
+		return fmt.Errorf("%q is not a rate limit like 10 or 10/1m", s)
+	}
+	limit := RateLimit{Requests: n, Per: v.p.Per}
+	if hasPer {
+		if limit.Per, err = time.ParseDuration(per); err != nil || limit.Per <= 0 {
+			return fmt.Errorf("%q is not a rate limit like 10 or 10/1m", s)
+		}
+	}
+	*v.p = limit
+	return nil
+}
+
+func (v rateLimitValue) String() string {
+	if v.p.Per == 0 {
+		return strconv.Itoa(v.p.Requests)
+	}
+	return fmt.Sprintf("%d/%s", v.p.Requests, v.p.Per)
+}
+
+// settings lists every setting of cfg, in the order they are printed. The
+// environment variable names predate the config file and are kept as is.
+// Settings stored in maps are bound to copies; commit writes them back.
+func settings(cfg *Config) (s []setting, commit func()) {
+	var commits []func()
+	commit = func() {
+		for _, c := range commits {
+			c()
+		}
+	}
+
+	s = []setting{
+		{key: "env", env: "ENV", value: stringValue{&cfg.Env}},
+		{key: "addr", env: "ADDR", value: stringValue{&cfg.Addr}},
+		{key: "public_url", env: "PUBLIC_URL", value: stringValue{&cfg.PublicURL}},
+		{key: "app_url", env: "APP_URL", value: stringValue{&cfg.AppURL}},
+		{key: "trusted_proxies", env: "TRUSTED_PROXIES", value: listValue{&cfg.TrustedProxies}},
+		{key: "shutdown_delay", env: "SHUTDOWN_DELAY", value: durationValue{&cfg.ShutdownDelay}},
+
+		{key: "db.host", env: "DB_HOST", value: stringValue{&cfg.DbConfig.Host}},
+		{key: "db.port", env: "DB_PORT", value: intValue{&cfg.DbConfig.Port}},
+		{key: "db.user", env: "DB_USER", value: stringValue{&cfg.DbConfig.User}},
+		{key: "db.password", env: "DB_PASSWORD", secret: true, value: stringValue{&cfg.DbConfig.Password}},
+		{key: "db.name", env: "DB_NAME", value: stringValue{&cfg.DbConfig.Dbname}},
+		{key: "db.timezone", env: "DB_TIMEZONE", value: stringValue{&cfg.DbConfig.Timezone}},
+		{key: "db.max_open_conns", env: "DB_MAX_OPEN_CONNS", value: intValue{&cfg.DbConfig.MaxOpenConns}},
+		{key: "db.max_idle_conns", env: "DB_MAX_IDLE_CONNS", value: intValue{&cfg.DbConfig.MaxIdleConns}},
+		{key: "db.max_idle_time", env: "DB_MAX_IDLE_TIME", value: durationValue{&cfg.DbConfig.MaxIdleTime}},
+		{key: "db.auto_migrate", env: "DB_AUTO_MIGRATE", value: boolValue{&cfg.DbConfig.AutoMigrate}},
+
+		{key: "redis.enabled", env: "REDIS_ENABLED", value: boolValue{&cfg.RedisConfig.Enabled}},
+		{key: "redis.addr", env: "REDIS_ADDR", value: stringValue{&cfg.RedisConfig.Addr}},
+		{key: "redis.password", env: "REDIS_PASSWORD", secret: true, value: stringValue{&cfg.RedisConfig.Password}},
+		{key: "redis.db", env: "REDIS_DB", value: intValue{&cfg.RedisConfig.Db}},
+
+		{key: "judge0.url", env: "JUDGE0_URL", value: stringValue{&cfg.Judge0.BaseURL}},
+		{key: "judge0.host", env: "X_JUDGE0_HOST", value: stringValue{&cfg.Judge0.Host}},
+		{key: "judge0.api_key", env: "X_JUDGE0_KEY", secret: true, value: stringValue{&cfg.Judge0.APIKey}},
+		{key: "judge0.timeout", env: "JUDGE0_TIMEOUT", value: durationValue{&cfg.Judge0.Timeout}},
+		{key: "languages.cache_ttl", env: "LANGUAGES_CACHE_TTL", value: durationValue{&cfg.Languages.CacheTTL}},
+
+		{key: "jwt.secret", env: "JWT_SECRET", secret: true, value: stringValue{&cfg.JWT.Secret}},
+
+		{key: "cors.allowed_origins", env: "CORS_ALLOWED_ORIGINS", value: listValue{&cfg.CORS.AllowedOrigins}},
+
+		{key: "cookie.secure", env: "COOKIE_SECURE", value: boolValue{&cfg.Cookie.Secure}},
+		{key: "cookie.domain", env: "COOKIE_DOMAIN", value: stringValue{&cfg.Cookie.Domain}},
+		{key: "cookie.same_site", env: "COOKIE_SAME_SITE", value: stringValue{&cfg.Cookie.SameSite}},
+
+		{key: "game.max_rooms", env: "GAME_MAX_ROOMS", value: intValue{&cfg.Game.MaxRooms}},
+		{key: "game.max_players_per_room", env: "GAME_MAX_PLAYERS_PER_ROOM", value: intValue{&cfg.Game.MaxPlayersPerRoom}},
+		{key: "game.submission_timeout", env: "GAME_SUBMISSION_TIMEOUT", value: durationValue{&cfg.Game.SubmissionTimeout}},
+
+		{key: "metrics.enabled", env: "METRICS_ENABLED", value: boolValue{&cfg.Metrics.Enabled}},
+		{key: "metrics.token", env: "METRICS_TOKEN", secret: true, value: stringValue{&cfg.Metrics.Token}},
+
+		{key: "tracing.exporter", env: "TRACING_EXPORTER", value: stringValue{&cfg.Tracing.Exporter}},
+		{key: "tracing.service_name", env: "OTEL_SERVICE_NAME", value: stringValue{&cfg.Tracing.ServiceName}},
+		{key: "tracing.endpoint", env: "TRACING_OTLP_ENDPOINT", value: stringValue{&cfg.Tracing.Endpoint}},
+		{key: "tracing.insecure", env: "TRACING_OTLP_INSECURE", value: boolValue{&cfg.Tracing.Insecure}},
+		{key: "tracing.sample_ratio", env: "TRACING_SAMPLE_RATIO", value: floatValue{&cfg.Tracing.SampleRatio}},
+
+		{key: "mail.driver", env: "MAIL_DRIVER", value: stringValue{&cfg.Mailer.Driver}},
+		{key: "mail.from", env: "MAIL_FROM", value: stringValue{&cfg.Mailer.From}},
+		{key: "mail.dir", env: "MAIL_DIR", value: stringValue{&cfg.Mailer.Dir}},
+		{key: "mail.smtp.host", env: "SMTP_HOST", value: stringValue{&cfg.Mailer.Host}},
+		{key: "mail.smtp.port", env: "SMTP_PORT", value: intValue{&cfg.Mailer.Port}},
+		{key: "mail.smtp.username", env: "SMTP_USERNAME", value: stringValue{&cfg.Mailer.Username}},
+		{key: "mail.smtp.password", env: "SMTP_PASSWORD", secret: true, value: stringValue{&cfg.Mailer.Password}},
+	}
+
+	// map 값은 주소를 가질 수 없으므로 복사본에 묶고 commit 에서 다시 넣는다
+	for _, provider := range []struct{ name, env, userInfoEnv string }{
+		{"github", "GITHUB", "GITHUB_USER_URL"},
+		{"google", "GOOGLE", "GOOGLE_USERINFO_URL"},
+	} {
+		name := provider.name
+		p := cfg.OAuthProviders[name]
+		commits = append(commits, func() { cfg.OAuthProviders[name] = p })
+		prefix := "oauth." + provider.name + "."
+		s = append(s,
+			setting{key: prefix + "client_id", env: provider.env + "_CLIENT_ID", value: stringValue{&p.ClientID}},
+			setting{key: prefix + "client_secret", env: provider.env + "_CLIENT_SECRET", secret: true, value: stringValue{&p.ClientSecret}},
+			setting{key: prefix + "auth_url", env: provider.env + "_AUTH_URL", value: stringValue{&p.AuthURL}},
+			setting{key: prefix + "token_url", env: provider.env + "_TOKEN_URL", value: stringValue{&p.TokenURL}},
+			setting{key: prefix + "userinfo_url", env: provider.userInfoEnv, value: stringValue{&p.UserInfoURL}},
+			setting{key: prefix + "scopes", value: listValue{&p.Scopes}},
+		)
+	}
+
+	for _, limit := range []struct {
+		route   string
+		key     string
+		userEnv string
+		ipEnv   string
+	}{
+		{"code.submit", "code_submit", "SUBMIT_RATE_LIMIT_USER", "SUBMIT_RATE_LIMIT_IP"},
+		{"users.verify.resend", "verify_resend", "", "VERIFY_RESEND_RATE_LIMIT_IP"},
+		{"users.password.forgot", "password_forgot", "", "PASSWORD_FORGOT_RATE_LIMIT_IP"},
+		{"users.signin.2fa", "signin_2fa", "", "SIGNIN_2FA_RATE_LIMIT_IP"},
+		{"users.password.reset", "password_reset", "", "PASSWORD_RESET_RATE_LIMIT_IP"},
+		{"users.export", "export", "EXPORT_RATE_LIMIT_USER", ""},
+		{"users.password.change", "password_change", "PASSWORD_CHANGE_RATE_LIMIT_USER", ""},
+		{"users.2fa.disable", "two_factor_disable", "TWO_FACTOR_DISABLE_RATE_LIMIT_USER", ""},
+		{"users.2fa.recovery_codes", "recovery_codes", "RECOVERY_CODES_RATE_LIMIT_USER", ""},
+		{"users.account.delete", "account_delete", "ACCOUNT_DELETE_RATE_LIMIT_USER", ""},
+	} {
+		route := limit.route
+		l := cfg.RateLimits[route]
+		commits = append(commits, func() { cfg.RateLimits[route] = l })
+		prefix := "rate_limits." + limit.key + "."
+		s = append(s,
+			setting{key: prefix + "per_user", env: limit.userEnv, value: rateLimitValue{&l.PerUser}},
+			setting{key: prefix + "per_ip", env: limit.ipEnv, value: rateLimitValue{&l.PerIP}},
+		)
+	}
+
+	for _, quota := range []struct{ role, env string }{
+		{"user", "SUBMISSION_QUOTA_USER"},
+		{"moderator", "SUBMISSION_QUOTA_MODERATOR"},
+	} {
+		role := quota.role
+		q := cfg.SubmissionQuotas[role]
+		commits = append(commits, func() { cfg.SubmissionQuotas[role] = q })
+		s = append(s, setting{key: "submission_quotas." + role, env: quota.env, value: intValue{&q}})
+	}
+
+	return s, commit
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
+)
+
+// EnvProd is the Env value of a production deployment. It turns on the
+// checks that refuse development defaults.
+const EnvProd = "prod"
+
+const minJWTSecretLength = 32
+
+// Validate reports every problem with cfg at once. Outside prod it only
+// checks that values make sense; in prod it also refuses the development
+// defaults and settings that are unsafe on the internet.
+func (cfg *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(cfg.Addr != "", "addr must not be empty")
+	check(cfg.ShutdownDelay >= 0, "shutdown_delay must not be negative")
+	check(cfg.DbConfig.Port > 0 && cfg.DbConfig.Port < 65536, "db.port %d is out of range", cfg.DbConfig.Port)
+	check(cfg.DbConfig.MaxOpenConns > 0, "db.max_open_conns must be positive")
+	check(cfg.DbConfig.MaxIdleConns >= 0, "db.max_idle_conns must not be negative")
+	check(cfg.JWT.Secret != "", "jwt.secret must not be empty")
+	check(cfg.Judge0.Timeout > 0, "judge0.timeout must be positive")
+	check(cfg.Languages.CacheTTL > 0, "languages.cache_ttl must be positive")
+	check(isURL(cfg.Judge0.BaseURL), "judge0.url %q is not an absolute URL", cfg.Judge0.BaseURL)
+	check(isURL(cfg.PublicURL), "public_url %q is not an absolute URL", cfg.PublicURL)
+	check(isURL(cfg.AppURL), "app_url %q is not an absolute URL", cfg.AppURL)
+	check(cfg.Game.MaxRooms > 0, "game.max_rooms must be positive")
+	check(cfg.Game.MaxPlayersPerRoom >= 2, "game.max_players_per_room must be at least 2")
+	check(cfg.Game.SubmissionTimeout > 0, "game.submission_timeout must be positive")
+
+	switch cfg.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be none, stdout or otlp, not %q", cfg.Tracing.Exporter))
+	}
+	check(cfg.Tracing.SampleRatio >= 0 && cfg.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(cfg.Tracing.Exporter != tracing.ExporterOTLP || cfg.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+
+	switch cfg.Cookie.SameSite {
+	case "lax", "strict":
+	case "none":
+		// 브라우저는 Secure 없는 SameSite=None 쿠키를 버림
+		check(cfg.Cookie.Secure, "cookie.same_site none requires cookie.secure")
+	default:
+		errs = append(errs, fmt.Errorf("cookie.same_site must be lax, strict or none, not %q", cfg.Cookie.SameSite))
+	}
+
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		check(origin == "*" || isURL(origin), "cors.allowed_origins: %q is not an origin", origin)
+	}
+	for _, proxy := range cfg.TrustedProxies {
+		check(isIPOrCIDR(proxy), "trusted_proxies: %q is not an IP address or CIDR", proxy)
+	}
+
+	all, _ := settings(cfg)
+	for _, s := range all {
+		if l, ok := s.value.(rateLimitValue); ok {
+			check(l.p.Requests == 0 || l.p.Per > 0, "%s needs a window, e.g. %d/1h", s.key, l.p.Requests)
+		}
+	}
+
+	if cfg.Env == EnvProd {
+		check(cfg.JWT.Secret != defaultJWTSecret && len(cfg.JWT.Secret) >= minJWTSecretLength,
+			"jwt.secret must be a random string of at least %d characters", minJWTSecretLength)
+		check(cfg.DbConfig.Password != defaultDbPassword, "db.password must not be the development default")
+		check(cfg.Cookie.Secure, "cookie.secure must be true")
+		check(strings.HasPrefix(cfg.PublicURL, "https://"), "public_url must use https")
+		check(strings.HasPrefix(cfg.AppURL, "https://"), "app_url must use https")
+		check(cfg.Mailer.Driver != mailer.DriverLog, "mail.driver %q does not deliver mail", mailer.DriverLog)
+		check(cfg.Judge0.APIKey != "" || !strings.Contains(cfg.Judge0.BaseURL, "rapidapi.com"),
+			"judge0.api_key is required for RapidAPI")
+		check(len(cfg.CORS.AllowedOrigins) > 0, "cors.allowed_origins must not be empty")
+		for _, origin := range cfg.CORS.AllowedOrigins {
+			check(origin != "*", "cors.allowed_origins must not contain * with credentials")
+			check(!isLocalhost(origin), "cors.allowed_origins must not contain %q", origin)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func isIPOrCIDR(s string) bool {
+	if _, _, err := net.ParseCIDR(s); err == nil {
+		return true
+	}
+	return net.ParseIP(s) != nil
+}
+
+func isLocalhost(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
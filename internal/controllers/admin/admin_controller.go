@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/admin"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AdminController struct {
+	AdminService admin.AdminService
+	audit        audit.Recorder
+	logger       *zap.SugaredLogger
+}
+
+var (
+	instance *AdminController
+	once     sync.Once
+)
+
+func NewAdminController(adminService admin.AdminService, recorder audit.Recorder, logger *zap.SugaredLogger) *AdminController {
+	once.Do(func() {
+		instance = &AdminController{
+			AdminService: adminService,
+			audit:        recorder,
+			logger:       logger,
+		}
+	})
+	return instance
+}
+
+func (ac *AdminController) HandleListAuditLogs(c *gin.Context) {
+	var query dtos.AuditLogListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query"})
+		return
+	}
+
+	page, err := ac.AdminService.ListAuditLogs(c.Request.Context(), query)
+	if err != nil {
+		ac.logger.Errorw("failed to list audit logs", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list audit logs"})
+		return
+	}
+
+	// 감사 로그 조회 자체도 기록
+	ac.audit.Record(audit.FromRequest(c, audit.ActionAdminAuditRead).With("query", c.Request.URL.RawQuery))
+	c.JSON(http.StatusOK, page)
+}
+
+func (ac *AdminController) HandleChangeUserRole(c *gin.Context) {
+	actor, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var dto dtos.ChangeRoleRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	change, err := ac.AdminService.ChangeUserRole(c.Request.Context(), actor.ID, uint(id), dto.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, admin.ErrUnknownRole), errors.Is(err, admin.ErrCannotChangeOwnRole):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ac.logger.Errorw("failed to change user role", "userID", id, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change role"})
+		}
+		return
+	}
+
+	ac.audit.Record(audit.FromRequest(c, audit.ActionRoleChange).
+		WithTarget("user", change.UserID).
+		With("old_role", change.OldRole).
+		With("new_role", change.NewRole))
+
+	c.JSON(http.StatusOK, gin.H{"ok": true, "change": change})
+}
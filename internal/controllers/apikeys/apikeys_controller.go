@@ -0,0 +1,117 @@
+package apikeys
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/apikeys"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type APIKeysController struct {
+	APIKeysService apikeys.APIKeysService
+	audit          audit.Recorder
+	logger         *zap.SugaredLogger
+}
+
+var (
+	instance *APIKeysController
+	once     sync.Once
+)
+
+func NewAPIKeysController(apiKeysService apikeys.APIKeysService, recorder audit.Recorder, logger *zap.SugaredLogger) *APIKeysController {
+	once.Do(func() {
+		instance = &APIKeysController{
+			APIKeysService: apiKeysService,
+			audit:          recorder,
+			logger:         logger,
+		}
+	})
+	return instance
+}
+
+func (ac *APIKeysController) HandleListKeys(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	keys, err := ac.APIKeysService.ListKeys(c.Request.Context(), user.ID)
+	if err != nil {
+		ac.logger.Errorw("failed to list api keys", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list api keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// HandleCreateKey returns the new key. It is the only time the key is shown.
+func (ac *APIKeysController) HandleCreateKey(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.CreateAPIKeyRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	created, err := ac.APIKeysService.CreateKey(c.Request.Context(), user.ID, dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikeys.ErrInvalidAPIKeyInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, apikeys.ErrTooManyAPIKeys):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ac.logger.Errorw("failed to create api key", "userID", user.ID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create api key"})
+		}
+		return
+	}
+
+	ac.audit.Record(audit.FromRequest(c, audit.ActionAPIKeyCreate).
+		WithTarget("api_key", created.APIKey.ID).
+		With("name", created.APIKey.Name).
+		With("scopes", created.APIKey.Scopes))
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (ac *APIKeysController) HandleDeleteKey(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	if err := ac.APIKeysService.DeleteKey(c.Request.Context(), user.ID, uint(id)); err != nil {
+		if errors.Is(err, apikeys.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ac.logger.Errorw("failed to delete api key", "userID", user.ID, "keyID", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete api key"})
+		return
+	}
+
+	ac.audit.Record(audit.FromRequest(c, audit.ActionAPIKeyDelete).WithTarget("api_key", id))
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
@@ -1,20 +1,38 @@
 package auth
 
 import (
-	"fmt"
+	"crypto/subtle"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/config"
 	"github.com/Dongmoon29/code_racer_api/internal/dtos"
 	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
 	"github.com/Dongmoon29/code_racer_api/internal/services/auth"
 	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
+const (
+	accessTokenCookie  = "token"
+	refreshTokenCookie = "refresh_token"
+	// 리프레시 토큰 쿠키는 /users 하위 경로(refresh, logout)에만 전송
+	refreshTokenCookiePath = "/api/v1/users"
+	// OAuth 로그인 시작한 브라우저와 콜백 브라우저가 같은지 확인
+	oauthStateCookie     = "oauth_state"
+	oauthStateCookiePath = "/api/v1/users/oauth"
+)
+
 type AuthController struct {
 	AuthService auth.AuthService
+	cookies     config.CookieConfig
+	audit       audit.Recorder
 	logger      *zap.SugaredLogger
 }
 
@@ -23,10 +41,12 @@ var (
 	once     sync.Once
 )
 
-func NewAuthController(authService auth.AuthService, logger *zap.SugaredLogger) *AuthController {
+func NewAuthController(authService auth.AuthService, cookies config.CookieConfig, recorder audit.Recorder, logger *zap.SugaredLogger) *AuthController {
 	once.Do(func() {
 		instance = &AuthController{
 			AuthService: authService,
+			cookies:     cookies,
+			audit:       recorder,
 			logger:      logger,
 		}
 	})
@@ -43,13 +63,58 @@ func (uc *AuthController) HandleSignup(c *gin.Context) {
 
 	user, err := uc.AuthService.CreateUser(dto)
 	if err != nil {
+		if utils.IsPasswordPolicyError(err) || errors.Is(err, utils.ErrUsernameInvalid) || errors.Is(err, utils.ErrUsernameReserved) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error signup"})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"ok": true, "user": user})
+	c.JSON(http.StatusCreated, gin.H{"ok": true, "user": user, "message": "verification email sent"})
 
 }
 
+func (uc *AuthController) HandleVerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Verification token is missing"})
+		return
+	}
+
+	if err := uc.AuthService.VerifyEmail(c.Request.Context(), token); err != nil {
+		if errors.Is(err, auth.ErrInvalidVerificationToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to verify email", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true, "message": "email verified"})
+}
+
+func (uc *AuthController) HandleResendVerification(c *gin.Context) {
+	var dto dtos.ResendVerificationRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := uc.AuthService.ResendVerification(c.Request.Context(), dto.Email); err != nil {
+		if errors.Is(err, auth.ErrVerificationThrottled) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to resend verification email", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email"})
+		return
+	}
+
+	// 계정 존재 여부와 무관하게 동일한 응답
+	c.JSON(http.StatusAccepted, gin.H{"ok": true, "message": "if the address belongs to an unverified account, a verification email has been sent"})
+}
+
 func (uc *AuthController) HandleLogout(c *gin.Context) {
 	userData, exists := c.Get("user")
 	if !exists {
@@ -66,16 +131,17 @@ func (uc *AuthController) HandleLogout(c *gin.Context) {
 	userID := userMap.ID
 	uc.AuthService.DeleteSession(c.Request.Context(), int(userID))
 
-	c.SetCookie(
-		"token",
-		"",
-		-1,
-		"/",
-		"",
-		false,
-		true,
-	)
+	if sessionID := c.GetString("session_id"); sessionID != "" {
+		if err := uc.AuthService.RevokeSession(c.Request.Context(), sessionID); err != nil {
+			uc.logger.Errorw("failed to revoke session on logout", "userID", userID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+			return
+		}
+	}
 
+	uc.clearAuthCookies(c)
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionLogout))
 	c.JSON(http.StatusOK, gin.H{"message": "logout successful"})
 }
 
@@ -97,34 +163,489 @@ func (uc *AuthController) HandleSignin(c *gin.Context) {
 		return
 	}
 
-	user, err := uc.AuthService.FindAndVerifyUserByEmail(signinRequestDto)
+	user, err := uc.AuthService.FindAndVerifyUserByEmail(c.Request.Context(), signinRequestDto, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		failure := audit.FromRequest(c, audit.ActionSignIn).With("email", signinRequestDto.Email)
+		var locked *auth.LockedError
+		switch {
+		case errors.As(err, &locked):
+			uc.audit.Record(failure.Failed("locked"))
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			uc.audit.Record(failure.Failed("invalid_credentials"))
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			uc.audit.Record(failure.Failed("email_not_verified"))
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			uc.logger.Errorw("failed to verify credentials", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign in"})
+		}
 		return
 	}
 
-	token, err := utils.GenerateJWT(fmt.Sprint(user.ID))
+	ctx := c.Request.Context()
+	enabled, err := uc.AuthService.TwoFactorEnabled(ctx, user.ID)
 	if err != nil {
+		uc.logger.Errorw("failed to check two-factor status", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign in"})
+		return
+	}
+
+	// 2FA 사용자는 코드 확인(POST /users/signin/2fa) 후에 세션 발급
+	if enabled {
+		challenge, err := uc.AuthService.CreateTwoFactorChallenge(ctx, user.ID)
+		if err != nil {
+			uc.logger.Errorw("failed to create two-factor challenge", "userID", user.ID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign in"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true, "two_factor_required": true, "challenge": challenge.Token, "expires_at": challenge.ExpiresAt})
+		return
+	}
+
+	uc.startSession(c, user, audit.ActionSignIn)
+}
+
+// HandleSigninTwoFactor is the second sign-in step for users with 2FA.
+func (uc *AuthController) HandleSigninTwoFactor(c *gin.Context) {
+	var dto dtos.TwoFactorSigninRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	user, err := uc.AuthService.VerifyTwoFactorChallenge(c.Request.Context(), dto.Challenge, dto.Code, c.ClientIP())
+	if err != nil {
+		var locked *auth.LockedError
+		if errors.As(err, &locked) {
+			uc.audit.Record(audit.FromRequest(c, audit.ActionSignInTwoFactor).Failed("locked"))
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidChallenge) || errors.Is(err, auth.ErrInvalidTwoFactorCode) || errors.Is(err, auth.ErrTwoFactorNotEnabled) {
+			uc.audit.Record(audit.FromRequest(c, audit.ActionSignInTwoFactor).Failed(err.Error()))
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to verify two-factor challenge", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign in"})
+		return
+	}
+
+	uc.startSession(c, user, audit.ActionSignInTwoFactor)
+}
+
+// startSession finishes a successful sign-in; action is the audited sign-in
+// step that completed it.
+func (uc *AuthController) startSession(c *gin.Context, user *mapper.MappedUser, action string) {
+	tokens, err := uc.AuthService.CreateSession(c.Request.Context(), user, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		uc.logger.Errorw("failed to create session", "userID", user.ID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
-	// if redis is enabled
 
-	// err = uc.AuthService.SaveSession(c.Request.Context(), user)
-	// if err != nil {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
-	// 	return
-	// }
+	uc.audit.Record(audit.FromRequest(c, action).WithActor(user.ID))
+
+	uc.setAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, gin.H{"ok": true, "user": user, "token": tokens.AccessToken, "tokens": tokens})
+}
+
+func (uc *AuthController) HandleEnrollTOTP(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	enrollment, err := uc.AuthService.BeginTOTPEnrollment(c.Request.Context(), user)
+	if err != nil {
+		if errors.Is(err, auth.ErrTwoFactorAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to start totp enrollment", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start enrollment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true, "enrollment": enrollment})
+}
+
+func (uc *AuthController) HandleConfirmTOTP(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.TwoFactorCodeRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	codes, err := uc.AuthService.ConfirmTOTPEnrollment(c.Request.Context(), user.ID, dto.Code)
+	if err != nil {
+		uc.handleTwoFactorError(c, user.ID, err)
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionTwoFactorEnable))
+
+	c.JSON(http.StatusOK, gin.H{"ok": true, "recovery_codes": codes})
+}
+
+func (uc *AuthController) HandleDisableTOTP(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.DisableTwoFactorRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := uc.AuthService.DisableTOTP(c.Request.Context(), user.ID, dto.Password, dto.Code, c.ClientIP()); err != nil {
+		if errors.Is(err, auth.ErrAccountLocked) {
+			uc.audit.Record(audit.FromRequest(c, audit.ActionTwoFactorDisable).Failed("locked"))
+		} else if errors.Is(err, auth.ErrInvalidCurrentPassword) || errors.Is(err, auth.ErrInvalidTwoFactorCode) {
+			uc.audit.Record(audit.FromRequest(c, audit.ActionTwoFactorDisable).Failed(err.Error()))
+		}
+		uc.handleTwoFactorError(c, user.ID, err)
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionTwoFactorDisable))
+
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (uc *AuthController) HandleRegenerateRecoveryCodes(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.TwoFactorCodeRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	codes, err := uc.AuthService.RegenerateRecoveryCodes(c.Request.Context(), user.ID, dto.Code, c.ClientIP())
+	if err != nil {
+		uc.handleTwoFactorError(c, user.ID, err)
+		return
+	}
 
-	// 5. 쿠키 설정
+	c.JSON(http.StatusOK, gin.H{"ok": true, "recovery_codes": codes})
+}
+
+func (uc *AuthController) handleTwoFactorError(c *gin.Context, userID uint, err error) {
+	var locked *auth.LockedError
+	switch {
+	case errors.As(err, &locked):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidTwoFactorCode), errors.Is(err, auth.ErrInvalidCurrentPassword):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrTwoFactorNotEnabled), errors.Is(err, auth.ErrTwoFactorNotEnrolled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		uc.logger.Errorw("two-factor request failed", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Two-factor request failed"})
+	}
+}
+
+func (uc *AuthController) HandleForgotPassword(c *gin.Context) {
+	var dto dtos.ForgotPasswordRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := uc.AuthService.ForgotPassword(c.Request.Context(), dto.Email); err != nil {
+		if errors.Is(err, auth.ErrPasswordResetThrottled) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to send password reset email", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send password reset email"})
+		return
+	}
+
+	// 계정 존재 여부와 무관하게 동일한 응답
+	c.JSON(http.StatusAccepted, gin.H{"ok": true, "message": "if the address belongs to an account, a password reset email has been sent"})
+}
+
+func (uc *AuthController) HandleResetPassword(c *gin.Context) {
+	var dto dtos.ResetPasswordRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := uc.AuthService.ResetPassword(c.Request.Context(), dto.Token, dto.Password); err != nil {
+		if errors.Is(err, auth.ErrInvalidResetToken) || utils.IsPasswordPolicyError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to reset password", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionPasswordReset))
+	uc.clearAuthCookies(c)
+	c.JSON(http.StatusOK, gin.H{"ok": true, "message": "password has been reset, please sign in again"})
+}
+
+// HandleChangePassword changes the password and signs out every session. The
+// current device gets a fresh session so the user stays signed in here.
+func (uc *AuthController) HandleChangePassword(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.ChangePasswordRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := uc.AuthService.ChangePassword(ctx, user.ID, dto.CurrentPassword, dto.NewPassword, c.ClientIP()); err != nil {
+		var locked *auth.LockedError
+		switch {
+		case errors.As(err, &locked):
+			uc.audit.Record(audit.FromRequest(c, audit.ActionPasswordChange).Failed("locked"))
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, auth.ErrInvalidCurrentPassword):
+			uc.audit.Record(audit.FromRequest(c, audit.ActionPasswordChange).Failed("invalid_current_password"))
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case utils.IsPasswordPolicyError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			uc.logger.Errorw("failed to change password", "userID", user.ID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		}
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionPasswordChange))
+
+	tokens, err := uc.AuthService.CreateSession(ctx, user, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		uc.logger.Errorw("failed to create session after password change", "userID", user.ID, "error", err)
+		uc.clearAuthCookies(c)
+		c.JSON(http.StatusOK, gin.H{"ok": true, "message": "password changed, please sign in again"})
+		return
+	}
+
+	uc.setAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, gin.H{"ok": true, "message": "password changed", "token": tokens.AccessToken, "tokens": tokens})
+}
+
+// HandleDeleteAccount schedules the account for deletion and signs out
+// everywhere. Signing in during the grace period restores the account.
+func (uc *AuthController) HandleDeleteAccount(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.DeleteAccountRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	deleteAt, err := uc.AuthService.ScheduleAccountDeletion(c.Request.Context(), user.ID, dto.Password, dto.Code, c.ClientIP())
+	if err != nil {
+		var locked *auth.LockedError
+		switch {
+		case errors.As(err, &locked):
+			uc.audit.Record(audit.FromRequest(c, audit.ActionAccountDeletion).Failed("locked"))
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, auth.ErrInvalidCurrentPassword), errors.Is(err, auth.ErrInvalidTwoFactorCode):
+			uc.audit.Record(audit.FromRequest(c, audit.ActionAccountDeletion).Failed(err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, auth.ErrDeletionAlreadyScheduled):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			uc.logger.Errorw("failed to schedule account deletion", "userID", user.ID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete account"})
+		}
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionAccountDeletion).With("delete_at", deleteAt))
+	uc.clearAuthCookies(c)
+	c.JSON(http.StatusAccepted, gin.H{
+		"ok":        true,
+		"delete_at": deleteAt,
+		"message":   "your account will be deleted; sign in before the deletion date to keep it",
+	})
+}
+
+func (uc *AuthController) HandleRefresh(c *gin.Context) {
+	var dto dtos.RefreshRequestDto
+	// 쿠키가 없는 클라이언트(CLI 등)는 바디로 전달
+	_ = c.ShouldBindJSON(&dto)
+
+	refreshToken := dto.RefreshToken
+	if refreshToken == "" {
+		refreshToken, _ = c.Cookie(refreshTokenCookie)
+	}
+	if refreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token is missing"})
+		return
+	}
+
+	tokens, err := uc.AuthService.Refresh(c.Request.Context(), refreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			uc.clearAuthCookies(c)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to refresh token", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	uc.setAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, gin.H{"ok": true, "token": tokens.AccessToken, "tokens": tokens})
+}
+
+// HandleOAuthStart redirects the browser to the provider's consent page.
+func (uc *AuthController) HandleOAuthStart(c *gin.Context) {
+	authURL, state, err := uc.AuthService.BeginOAuth(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		if errors.Is(err, auth.ErrUnknownProvider) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		uc.logger.Errorw("failed to start oauth sign-in", "provider", c.Param("provider"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start sign-in"})
+		return
+	}
+
+	// 공급자에서 돌아오는 요청은 cross-site 이동이라 strict 이면 쿠키가 빠짐
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, int(auth.OAuthStateTTL.Seconds()), oauthStateCookiePath, uc.cookies.Domain, uc.cookies.Secure, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// HandleOAuthCallback finishes the sign-in and sends the browser back to the
+// web client with the auth cookies set.
+func (uc *AuthController) HandleOAuthCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	cookieState, _ := c.Cookie(oauthStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, "", -1, oauthStateCookiePath, uc.cookies.Domain, uc.cookies.Secure, true)
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", providerErr))
+		return
+	}
+
+	state := c.Query("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", "invalid_state"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := uc.AuthService.CompleteOAuth(ctx, provider, state, c.Query("code"))
+	if err != nil {
+		code := "server_error"
+		switch {
+		case errors.Is(err, auth.ErrUnknownProvider):
+			code = "unknown_provider"
+		case errors.Is(err, auth.ErrInvalidOAuthState):
+			code = "invalid_state"
+		case errors.Is(err, auth.ErrOAuthExchangeFailed):
+			code = "access_denied"
+		case errors.Is(err, auth.ErrOAuthEmailMissing):
+			code = "email_required"
+		default:
+			uc.logger.Errorw("oauth sign-in failed", "provider", provider, "error", err)
+		}
+		uc.audit.Record(audit.FromRequest(c, audit.ActionSignInOAuth).With("provider", provider).Failed(code))
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", code))
+		return
+	}
+
+	// 외부 로그인도 2FA가 켜져 있으면 코드 확인 단계를 거침
+	enabled, err := uc.AuthService.TwoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		uc.logger.Errorw("failed to check two-factor status", "userID", user.ID, "error", err)
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", "server_error"))
+		return
+	}
+	if enabled {
+		challenge, err := uc.AuthService.CreateTwoFactorChallenge(ctx, user.ID)
+		if err != nil {
+			uc.logger.Errorw("failed to create two-factor challenge", "userID", user.ID, "error", err)
+			c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", "server_error"))
+			return
+		}
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("challenge", challenge.Token))
+		return
+	}
+
+	tokens, err := uc.AuthService.CreateSession(ctx, user, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		uc.logger.Errorw("failed to create session", "userID", user.ID, "error", err)
+		c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("error", "server_error"))
+		return
+	}
+
+	uc.audit.Record(audit.FromRequest(c, audit.ActionSignInOAuth).WithActor(user.ID).With("provider", provider))
+	uc.setAuthCookies(c, tokens)
+	c.Redirect(http.StatusFound, uc.AuthService.OAuthCompleteURL("", ""))
+}
+
+func (uc *AuthController) setAuthCookies(c *gin.Context, tokens *auth.TokenPair) {
+	c.SetSameSite(uc.cookies.SameSiteMode())
 	c.SetCookie(
-		"token",
-		token,
-		3600,
+		accessTokenCookie,
+		tokens.AccessToken,
+		int(utils.AccessTokenTTL.Seconds()),
 		"/",
-		"",
-		false, // Secure = false (HTTPS가 아닌 HTTP에서 테스트용)
-		true,  // HttpOnly
+		uc.cookies.Domain,
+		uc.cookies.Secure,
+		true, // HttpOnly
 	)
-	c.JSON(http.StatusOK, gin.H{"ok": true, "user": user, "token": token})
+	c.SetCookie(
+		refreshTokenCookie,
+		tokens.RefreshToken,
+		int(utils.RefreshTokenTTL.Seconds()),
+		refreshTokenCookiePath,
+		uc.cookies.Domain,
+		uc.cookies.Secure,
+		true,
+	)
+}
+
+func (uc *AuthController) clearAuthCookies(c *gin.Context) {
+	c.SetSameSite(uc.cookies.SameSiteMode())
+	c.SetCookie(accessTokenCookie, "", -1, "/", uc.cookies.Domain, uc.cookies.Secure, true)
+	c.SetCookie(refreshTokenCookie, "", -1, refreshTokenCookiePath, uc.cookies.Domain, uc.cookies.Secure, true)
 }
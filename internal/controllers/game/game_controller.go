@@ -1,11 +1,15 @@
 package game
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 
-	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
 	"github.com/Dongmoon29/code_racer_api/internal/services/game"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -14,6 +18,7 @@ import (
 
 type GameController struct {
 	GameService game.GameService
+	audit       audit.Recorder
 	logger      *zap.SugaredLogger
 	upgrader    websocket.Upgrader
 }
@@ -23,23 +28,44 @@ var (
 	once     sync.Once
 )
 
-func NewGameController(gameService game.GameService, logger *zap.SugaredLogger) *GameController {
+// NewGameController creates the game controller. allowedOrigins are the web
+// client origins (the CORS list) allowed to open the game WebSocket.
+func NewGameController(gameService game.GameService, allowedOrigins []string, recorder audit.Recorder, logger *zap.SugaredLogger) *GameController {
 	once.Do(func() {
 		instance = &GameController{
 			GameService: gameService,
+			audit:       recorder,
 			logger:      logger,
 			upgrader: websocket.Upgrader{
 				ReadBufferSize:  1024,
 				WriteBufferSize: 1024,
-				CheckOrigin: func(r *http.Request) bool {
-					return true
-				},
+				CheckOrigin:     checkOrigin(allowedOrigins),
 			},
 		}
 	})
 	return instance
 }
 
+// checkOrigin protects the cookie-authenticated WebSocket from cross-site
+// hijacking: browsers always send Origin, which must be the API itself or an
+// allowed origin. Requests without Origin come from non-browser clients,
+// which cannot carry a victim's cookie.
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || strings.EqualFold(strings.TrimRight(allowed, "/"), origin) {
+				return true
+			}
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
 func (gc *GameController) HandleGetGameRooms(c *gin.Context) {
 	rooms := gc.GameService.GetGameRooms()
 	c.JSON(http.StatusOK, gin.H{
@@ -47,11 +73,32 @@ func (gc *GameController) HandleGetGameRooms(c *gin.Context) {
 	})
 }
 
+func (gc *GameController) HandleIssueWebSocketTicket(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ticket, expiresAt, err := gc.GameService.IssueWebSocketTicket(c.Request.Context(), user.ID, c.GetString("session_id"))
+	if err != nil {
+		gc.logger.Errorw("failed to issue websocket ticket", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue ticket"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"ticket": ticket, "expires_at": expiresAt})
+}
+
 func (gc *GameController) HandleGameWebSocket(c *gin.Context) {
 	gc.logger.Debug("웹소켓 연결 시도 감지됨")
-	gc.logger.Debug("요청 헤더:")
-	for name, values := range c.Request.Header {
-		gc.logger.Debug(fmt.Sprintf("  %s: %v", name, values))
+
+	// 업그레이드 전에 사용자 정보 검증 (실패 시 일반 HTTP 응답)
+	convertedUser, ok := middlewares.CurrentUser(c)
+	if !ok {
+		gc.logger.Error("인증된 사용자 정보를 찾을 수 없음")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: Please login"})
+		return
 	}
 
 	// 웹소켓 연결 업그레이드
@@ -62,34 +109,11 @@ func (gc *GameController) HandleGameWebSocket(c *gin.Context) {
 	}
 	gc.logger.Debug("웹소켓 연결 업그레이드 성공")
 
-	// 사용자 정보 검증
-	user, exists := c.Get("user")
-	if !exists {
-		gc.logger.Error("인증된 사용자 정보를 찾을 수 없음")
-		conn.WriteMessage(websocket.TextMessage, []byte("Unauthorized: Please login"))
-		conn.Close()
-		return
-	}
-
-	convertedUser, ok := user.(*mapper.MappedUser)
-	if !ok {
-		gc.logger.Error("사용자 객체 변환 실패")
-		conn.WriteMessage(websocket.TextMessage, []byte("Server error: Invalid user data"))
-		conn.Close()
-		return
-	}
-	if convertedUser == nil {
-		gc.logger.Error("convertedUser가 nil입니다")
-		conn.WriteMessage(websocket.TextMessage, []byte("Server error: User data is nil"))
-		conn.Close()
-		return
-	}
-
 	// 게임 서비스로 연결 위임
 	userID := convertedUser.ID
 	gc.logger.Debug("게임 웹소켓 연결 처리 중", zap.Uint("userID", userID))
 
-	err = gc.GameService.ConnectGameSocketConnect(conn, userID)
+	err = gc.GameService.ConnectGameSocketConnect(c.Request.Context(), conn, userID, middlewares.PreferredLocales(c))
 	if err != nil {
 		gc.logger.Error("게임 웹소켓 연결 실패", zap.Error(err))
 		conn.WriteMessage(websocket.TextMessage, []byte("Failed to connect to game service"))
@@ -102,3 +126,57 @@ func (gc *GameController) HandleGetGameManagerStatus(c *gin.Context) {
 	manager := gc.GameService.DebugGameManager()
 	c.JSON(http.StatusOK, gin.H{"manager": manager})
 }
+
+// HandleRemovePlayer kicks a player out of a room, optionally banning them
+// from rejoining it.
+func (gc *GameController) HandleRemovePlayer(c *gin.Context) {
+	var dto dtos.RemovePlayerRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	roomID := c.Param("id")
+	if err := gc.GameService.RemovePlayer(roomID, dto.UserID, dto.Ban); err != nil {
+		if errors.Is(err, game.ErrRoomNotFound) || errors.Is(err, game.ErrPlayerNotInRoom) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		gc.logger.Errorw("failed to remove player", "roomID", roomID, "userID", dto.UserID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove player"})
+		return
+	}
+
+	action := audit.ActionRoomKick
+	if dto.Ban {
+		action = audit.ActionRoomBan
+	}
+	gc.audit.Record(audit.FromRequest(c, action).
+		WithTarget("user", dto.UserID).
+		With("room_id", roomID).
+		With("reason", dto.Reason))
+
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+// HandleListRooms lists every live room for operators, including rooms in
+// play, unlike the lobby list.
+func (gc *GameController) HandleListRooms(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"rooms": gc.GameService.ListRooms()})
+}
+
+func (gc *GameController) HandleCloseRoom(c *gin.Context) {
+	roomID := c.Param("id")
+	if err := gc.GameService.CloseRoom(roomID); err != nil {
+		if errors.Is(err, game.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		gc.logger.Errorw("failed to close room", "roomID", roomID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to close room"})
+		return
+	}
+
+	gc.audit.Record(audit.FromRequest(c, audit.ActionRoomClose).WithTarget("room", roomID))
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
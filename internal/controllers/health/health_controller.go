@@ -0,0 +1,63 @@
+package health
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/health"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HealthController struct {
+	checker *health.Checker
+	logger  *zap.SugaredLogger
+}
+
+var (
+	instance *HealthController
+	once     sync.Once
+)
+
+func NewHealthController(checker *health.Checker, logger *zap.SugaredLogger) *HealthController {
+	once.Do(func() {
+		instance = &HealthController{
+			checker: checker,
+			logger:  logger,
+		}
+	})
+	return instance
+}
+
+// HandleHealthz answers as long as the process serves HTTP.
+func (hc *HealthController) HandleHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": health.StatusOK,
+		"uptime": hc.checker.Uptime().Round(time.Second).String(),
+	})
+}
+
+// HandleLivez fails when the process is stuck and should be restarted.
+func (hc *HealthController) HandleLivez(c *gin.Context) {
+	hc.respond(c, hc.checker.Live(c.Request.Context()))
+}
+
+// HandleReadyz fails when a dependency is down or the server is shutting
+// down, so the instance should not get new traffic.
+func (hc *HealthController) HandleReadyz(c *gin.Context) {
+	hc.respond(c, hc.checker.Ready(c.Request.Context()))
+}
+
+func (hc *HealthController) respond(c *gin.Context, report health.Report) {
+	// 프로브 응답이 캐시되면 안 됨
+	c.Header("Cache-Control", "no-store")
+	if !report.OK() {
+		if report.Status != health.StatusDraining {
+			hc.logger.Warnw("health check failing", "path", c.FullPath(), "checks", report.Checks)
+		}
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
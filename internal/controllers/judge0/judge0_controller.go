@@ -1,21 +1,26 @@
 package judge0
 
 import (
-	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
 	"github.com/Dongmoon29/code_racer_api/internal/services/judge0"
-	"github.com/Dongmoon29/code_racer_api/internal/utils/client"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/services/submissions"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type Judge0Controller struct {
-	Judge0Service judge0.Judge0Service
-	logger        *zap.SugaredLogger
+	Judge0Service      judge0.Judge0Service
+	LanguagesService   languages.LanguagesService
+	SubmissionsService submissions.SubmissionsService
+	logger             *zap.SugaredLogger
 }
 
 var (
@@ -23,49 +28,160 @@ var (
 	once     sync.Once
 )
 
-func NewJudge0Controller(judge0Service judge0.Judge0Service, logger *zap.SugaredLogger) *Judge0Controller {
+func NewJudge0Controller(judge0Service judge0.Judge0Service, languagesService languages.LanguagesService, submissionsService submissions.SubmissionsService, logger *zap.SugaredLogger) *Judge0Controller {
 	once.Do(func() {
 		instance = &Judge0Controller{
-			Judge0Service: judge0Service,
-			logger:        logger,
+			Judge0Service:      judge0Service,
+			LanguagesService:   languagesService,
+			SubmissionsService: submissionsService,
+			logger:             logger,
 		}
 	})
 	return instance
 }
 
 func (jc *Judge0Controller) GetAbout(c *gin.Context) {
-	client := client.J0Client
-	res, err := client.GET("/about")
+	response, err := jc.LanguagesService.GetAbout(c.Request.Context())
 	if err != nil {
-
 		c.JSON(500, gin.H{
 			"error":   "Failed to fetch data from /about",
 			"message": err.Error(),
 		})
 		return
 	}
+	c.JSON(200, gin.H{"response": response})
+}
 
-	var response map[string]interface{}
+func (jc *Judge0Controller) HandleGetLanguages(c *gin.Context) {
+	includeDisabled := c.Query("all") == "true"
 
-	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
-		fmt.Printf("Error parsing JSON response: %v\n", err)
-		c.JSON(500, gin.H{"error": "Failed to parse JSON response", "detail": err.Error()})
+	langs, err := jc.LanguagesService.GetLanguages(c.Request.Context(), includeDisabled)
+	if err != nil {
+		jc.logger.Errorw("failed to load languages", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load languages"})
 		return
 	}
-	c.JSON(200, gin.H{"response": response})
+	c.JSON(http.StatusOK, gin.H{"languages": langs})
 }
 
+// HandleCreateCodeSubmission runs code without a problem and responds with
+// the Judge0 submission as is, the shape existing clients rely on. The run is
+// stored like any other submission.
 func (jc *Judge0Controller) HandleCreateCodeSubmission(c *gin.Context) {
 	var codeSubmissionRequestDto dtos.CodeSubmissionRequest
 	if err := c.ShouldBindJSON(&codeSubmissionRequestDto); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid dto"})
 		return
 	}
-	result, err := jc.Judge0Service.CreateCodeSubmission(codeSubmissionRequestDto)
+	if codeSubmissionRequestDto.ProblemID != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "submit solutions to problems with POST /code/submissions"})
+		return
+	}
+
+	result, ok := jc.submit(c, codeSubmissionRequestDto)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, result.Result.Raw)
+}
+
+// HandleSubmit stores a submission and responds with it and either the run
+// result or, when a problem is given, the verdict against its test cases.
+func (jc *Judge0Controller) HandleSubmit(c *gin.Context) {
+	var codeSubmissionRequestDto dtos.CodeSubmissionRequest
+	if err := c.ShouldBindJSON(&codeSubmissionRequestDto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid dto"})
+		return
+	}
+
+	result, ok := jc.submit(c, codeSubmissionRequestDto)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// submit validates and runs a submission. On failure it writes the error
+// response and reports false.
+func (jc *Judge0Controller) submit(c *gin.Context, dto dtos.CodeSubmissionRequest) (*submissions.SubmitResult, bool) {
+	if _, err := jc.LanguagesService.Validate(c.Request.Context(), dto.LanguageID); err != nil {
+		if errors.Is(err, languages.ErrUnsupportedLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "unsupported language"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "error"})
+		return nil, false
+	}
+
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+
+	result, err := jc.SubmissionsService.Submit(c.Request.Context(), user.ID, dto)
 	if err != nil {
+		if errors.Is(err, submissions.ErrProblemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "problem not found"})
+			return nil, false
+		}
+		jc.logger.Errorw("failed to create submission", "userID", user.ID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "error"})
+		return nil, false
+	}
+
+	return result, true
+}
+
+func (jc *Judge0Controller) HandleGetSubmissions(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	var query dtos.SubmissionListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query"})
+		return
+	}
+
+	page, err := jc.SubmissionsService.List(c.Request.Context(), user.ID, query)
+	if err != nil {
+		jc.logger.Errorw("failed to list submissions", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list submissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (jc *Judge0Controller) HandleGetSubmission(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid submission id"})
+		return
+	}
+
+	submission, err := jc.SubmissionsService.Get(c.Request.Context(), user, uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "submission not found"})
+		default:
+			jc.logger.Errorw("failed to get submission", "submissionID", id, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get submission"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"submission": submission})
 }
@@ -0,0 +1,150 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/services/practice"
+	"github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PracticeController struct {
+	PracticeService practice.PracticeService
+	logger          *zap.SugaredLogger
+}
+
+var (
+	instance *PracticeController
+	once     sync.Once
+)
+
+func NewPracticeController(practiceService practice.PracticeService, logger *zap.SugaredLogger) *PracticeController {
+	once.Do(func() {
+		instance = &PracticeController{
+			PracticeService: practiceService,
+			logger:          logger,
+		}
+	})
+	return instance
+}
+
+func (pc *PracticeController) HandleStartPractice(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.StartPracticeRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	started, err := pc.PracticeService.Start(c.Request.Context(), user.ID, dto.ProblemID, middlewares.PreferredLocales(c))
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, started)
+}
+
+func (pc *PracticeController) HandleRunSamples(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, dto, ok := bindPracticeCode(c)
+	if !ok {
+		return
+	}
+
+	results, err := pc.PracticeService.RunSamples(c.Request.Context(), user.ID, sessionID, dto)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+func (pc *PracticeController) HandleSubmit(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, dto, ok := bindPracticeCode(c)
+	if !ok {
+		return
+	}
+
+	result, err := pc.PracticeService.Submit(c.Request.Context(), user.ID, sessionID, dto)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (pc *PracticeController) HandleGetPersonalBests(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bests, err := pc.PracticeService.ListPersonalBests(c.Request.Context(), user.ID)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"personal_bests": bests})
+}
+
+func (pc *PracticeController) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, problems.ErrProblemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "problem not found"})
+	case errors.Is(err, practice.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "practice session not found"})
+	case errors.Is(err, languages.ErrUnsupportedLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported language"})
+	case errors.Is(err, judge.ErrNoTestCases):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "problem has no test cases"})
+	default:
+		pc.logger.Errorw("practice request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+func bindPracticeCode(c *gin.Context) (uint, dtos.PracticeCodeRequestDto, bool) {
+	var dto dtos.PracticeCodeRequestDto
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid practice session id"})
+		return 0, dto, false
+	}
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return 0, dto, false
+	}
+
+	return uint(sessionID), dto, true
+}
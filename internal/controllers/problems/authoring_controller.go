@@ -0,0 +1,267 @@
+package problems
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	"github.com/gin-gonic/gin"
+)
+
+func (pc *ProblemsController) HandleListAllProblems(c *gin.Context) {
+	list, err := pc.ProblemsService.ListForAuthoring(c.Request.Context())
+	if err != nil {
+		pc.logger.Errorw("failed to list problems", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list problems"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problems": list})
+}
+
+func (pc *ProblemsController) HandleGetProblemForEdit(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	problem, err := pc.ProblemsService.GetForAuthoring(c.Request.Context(), problemID)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problem": problem})
+}
+
+func (pc *ProblemsController) HandleCreateProblem(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.ProblemRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	problem, err := pc.ProblemsService.CreateProblem(c.Request.Context(), user.ID, dto)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	pc.audit.Record(audit.FromRequest(c, audit.ActionProblemCreate).
+		WithTarget("problem", problem.ID).
+		With("slug", problem.Slug))
+	c.JSON(http.StatusCreated, gin.H{"problem": problem})
+}
+
+// HandleUpdateProblem replaces the problem. The body must carry the version
+// it was based on; a stale version is rejected with 409.
+func (pc *ProblemsController) HandleUpdateProblem(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	var dto dtos.ProblemRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if dto.Version == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version is required"})
+		return
+	}
+
+	problem, err := pc.ProblemsService.UpdateProblem(c.Request.Context(), user.ID, problemID, dto)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	pc.audit.Record(audit.FromRequest(c, audit.ActionProblemUpdate).
+		WithTarget("problem", problem.ID).
+		With("version", problem.Version))
+	c.JSON(http.StatusOK, gin.H{"problem": problem})
+}
+
+func (pc *ProblemsController) HandlePublishProblem(c *gin.Context) {
+	pc.setPublished(c, true)
+}
+
+func (pc *ProblemsController) HandleUnpublishProblem(c *gin.Context) {
+	pc.setPublished(c, false)
+}
+
+func (pc *ProblemsController) setPublished(c *gin.Context, published bool) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := pc.ProblemsService.SetPublished(c.Request.Context(), problemID, published); err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	action := audit.ActionProblemPublish
+	if !published {
+		action = audit.ActionProblemUnpublish
+	}
+	pc.audit.Record(audit.FromRequest(c, action).WithTarget("problem", problemID))
+	c.JSON(http.StatusOK, gin.H{"ok": true, "is_published": published})
+}
+
+func (pc *ProblemsController) HandleListProblemVersions(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	versions, err := pc.ProblemsService.ListVersions(c.Request.Context(), problemID)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+func (pc *ProblemsController) HandleGetProblemVersion(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return
+	}
+
+	problem, err := pc.ProblemsService.GetVersion(c.Request.Context(), problemID, version)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problem": problem})
+}
+
+// HandleValidateProblem runs the stored reference solution against every
+// test case without changing anything.
+func (pc *ProblemsController) HandleValidateProblem(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	verdict, err := pc.ProblemsService.VerifyStoredReference(c.Request.Context(), problemID)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true, "verdict": verdict})
+}
+
+func (pc *ProblemsController) HandleExportProblem(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	// 실패하면 JSON 에러를 보낼 수 있도록 버퍼에 먼저 쓴다
+	var buf bytes.Buffer
+	problem, err := pc.ProblemsService.ExportPackage(c.Request.Context(), problemID, &buf)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, problem.Slug))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// HandleImportProblem accepts a zipped package in the "package" form field.
+func (pc *ProblemsController) HandleImportProblem(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, problems.MaxPackageSize+1<<20)
+	header, err := c.FormFile("package")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a zip file in the package field is required"})
+		return
+	}
+	if header.Size > problems.MaxPackageSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "package is too large"})
+		return
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read package"})
+		return
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read package"})
+		return
+	}
+
+	result, err := pc.ProblemsService.ImportPackage(c.Request.Context(), user.ID, data)
+	if err != nil {
+		pc.handleAuthoringError(c, err)
+		return
+	}
+
+	pc.audit.Record(audit.FromRequest(c, audit.ActionProblemImport).
+		WithTarget("problem", result.Problem.ID).
+		With("slug", result.Problem.Slug).
+		With("created", result.Created).
+		With("version", result.Problem.Version))
+
+	status := http.StatusOK
+	if result.Created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+func (pc *ProblemsController) handleAuthoringError(c *gin.Context, err error) {
+	var refErr *problems.ReferenceError
+	switch {
+	case errors.As(err, &refErr):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "verdict": refErr.Verdict})
+	case errors.Is(err, problems.ErrProblemNotFound), errors.Is(err, problems.ErrVersionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, problems.ErrProblemConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, problems.ErrInvalidProblem), errors.Is(err, problems.ErrInvalidPackage),
+		errors.Is(err, problems.ErrNoReference):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		pc.logger.Errorw("problem authoring request failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,96 @@
+package problems
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ProblemsController struct {
+	ProblemsService problems.ProblemsService
+	audit           audit.Recorder
+	logger          *zap.SugaredLogger
+}
+
+var (
+	instance *ProblemsController
+	once     sync.Once
+)
+
+func NewProblemsController(problemsService problems.ProblemsService, recorder audit.Recorder, logger *zap.SugaredLogger) *ProblemsController {
+	once.Do(func() {
+		instance = &ProblemsController{
+			ProblemsService: problemsService,
+			audit:           recorder,
+			logger:          logger,
+		}
+	})
+	return instance
+}
+
+func (pc *ProblemsController) HandleGetProblems(c *gin.Context) {
+	list, err := pc.ProblemsService.List(c.Request.Context(), middlewares.PreferredLocales(c))
+	if err != nil {
+		pc.logger.Errorw("failed to list problems", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list problems"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problems": list})
+}
+
+func (pc *ProblemsController) HandleGetProblem(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	// 저장된 locale, Accept-Language, 문제의 기본 언어 순으로 선택
+	problem, err := pc.ProblemsService.Get(c.Request.Context(), problemID, middlewares.PreferredLocales(c))
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"problem": problem})
+}
+
+func (pc *ProblemsController) HandleGetProblemStats(c *gin.Context) {
+	problemID, ok := problemIDParam(c)
+	if !ok {
+		return
+	}
+
+	stats, err := pc.ProblemsService.GetStats(c.Request.Context(), problemID)
+	if err != nil {
+		pc.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
+func (pc *ProblemsController) handleError(c *gin.Context, err error) {
+	if errors.Is(err, problems.ErrProblemNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "problem not found"})
+		return
+	}
+	pc.logger.Errorw("problem request failed", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func problemIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid problem id"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,118 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/middlewares"
+	"github.com/Dongmoon29/code_racer_api/internal/services/users"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type UsersController struct {
+	UsersService users.UsersService
+	logger       *zap.SugaredLogger
+}
+
+var (
+	instance *UsersController
+	once     sync.Once
+)
+
+func NewUsersController(usersService users.UsersService, logger *zap.SugaredLogger) *UsersController {
+	once.Do(func() {
+		instance = &UsersController{
+			UsersService: usersService,
+			logger:       logger,
+		}
+	})
+	return instance
+}
+
+func (uc *UsersController) HandleUpdateProfile(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto dtos.UpdateProfileRequestDto
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	updated, err := uc.UsersService.UpdateProfile(c.Request.Context(), user.ID, dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, users.ErrInvalidProfile):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, users.ErrUsernameTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			uc.logger.Errorw("failed to update profile", "userID", user.ID, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": updated})
+}
+
+func (uc *UsersController) HandleGetPublicProfile(c *gin.Context) {
+	profile, err := uc.UsersService.GetPublicProfile(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		if errors.Is(err, users.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		uc.logger.Errorw("failed to load public profile", "username", c.Param("username"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// HandleExport sends the user's personal data as JSON, or as a ZIP archive
+// with ?format=zip.
+func (uc *UsersController) HandleExport(c *gin.Context) {
+	user, ok := middlewares.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or zip"})
+		return
+	}
+
+	export, err := uc.UsersService.ExportData(c.Request.Context(), user.ID)
+	if err != nil {
+		uc.logger.Errorw("failed to export user data", "userID", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export data"})
+		return
+	}
+
+	filename := fmt.Sprintf("code-racer-%s-%s.%s", user.Username, export.ExportedAt.Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+
+	if format == "json" {
+		c.IndentedJSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if err := export.WriteZip(c.Writer); err != nil {
+		// 헤더가 이미 전송되었으므로 로그만 남김
+		uc.logger.Errorw("failed to write export archive", "userID", user.ID, "error", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -16,7 +17,8 @@ func New(cfg config.DbConfig) (*gorm.DB, error) {
 
 	var err error
 	dsn := cfg.GetPostgresDsn()
-	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// 중복 키 등 DB 에러를 gorm.ErrDuplicatedKey 같은 공통 에러로 변환
+	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
@@ -30,5 +32,10 @@ func New(cfg config.DbConfig) (*gorm.DB, error) {
 	sqlDb.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDb.SetConnMaxIdleTime(cfg.MaxIdleTime)
 
+	// 트레이싱이 꺼져 있으면 span 을 만들지 않으므로 항상 등록
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %v", err)
+	}
+
 	return db, nil
 }
@@ -0,0 +1,99 @@
+CREATE TABLE roles (
+    id   BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    CONSTRAINT uni_roles_name UNIQUE (name)
+);
+
+CREATE TABLE permissions (
+    id   BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    CONSTRAINT uni_permissions_name UNIQUE (name)
+);
+
+CREATE TABLE role_permissions (
+    role_id       BIGINT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission_id BIGINT NOT NULL REFERENCES permissions (id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE users (
+    id                    BIGSERIAL PRIMARY KEY,
+    username              TEXT        NOT NULL,
+    password              TEXT        NOT NULL,
+    email                 TEXT        NOT NULL,
+    role_id               BIGINT      NOT NULL REFERENCES roles (id),
+    is_active             BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at            TIMESTAMPTZ,
+    display_name          VARCHAR(50),
+    bio                   VARCHAR(500),
+    avatar_url            VARCHAR(512),
+    preferred_language    VARCHAR(32),
+    editor_theme          VARCHAR(32),
+    rating                BIGINT      NOT NULL DEFAULT 1200,
+    deletion_scheduled_at TIMESTAMPTZ,
+    deleted_at            TIMESTAMPTZ,
+    CONSTRAINT uni_users_username UNIQUE (username),
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE INDEX idx_users_deletion_scheduled_at ON users (deletion_scheduled_at);
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE user_identities (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    provider   VARCHAR(32) NOT NULL,
+    subject    TEXT        NOT NULL,
+    email      TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+CREATE UNIQUE INDEX idx_identity_provider_subject ON user_identities (provider, subject);
+
+CREATE TABLE auth_sessions (
+    id         VARCHAR(36) PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    user_agent TEXT,
+    ip         TEXT,
+    created_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+CREATE INDEX idx_auth_sessions_user_id ON auth_sessions (user_id);
+
+CREATE TABLE refresh_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    session_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE password_reset_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+CREATE UNIQUE INDEX idx_password_reset_tokens_token_hash ON password_reset_tokens (token_hash);
+
+CREATE TABLE user_totps (
+    user_id        BIGINT PRIMARY KEY REFERENCES users (id),
+    secret         TEXT   NOT NULL,
+    confirmed_at   TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ
+);
+
+CREATE TABLE recovery_codes (
+    id        BIGSERIAL PRIMARY KEY,
+    user_id   BIGINT      NOT NULL REFERENCES users (id),
+    code_hash VARCHAR(64) NOT NULL,
+    used_at   TIMESTAMPTZ
+);
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
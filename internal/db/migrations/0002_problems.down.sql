@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS submissions;
+DROP TABLE IF EXISTS problem_templates;
+DROP TABLE IF EXISTS test_cases;
+DROP TABLE IF EXISTS problems;
@@ -0,0 +1,53 @@
+CREATE TABLE problems (
+    id                  BIGSERIAL PRIMARY KEY,
+    slug                TEXT             NOT NULL,
+    title               TEXT             NOT NULL,
+    statement           TEXT,
+    difficulty          TEXT,
+    time_limit          DOUBLE PRECISION DEFAULT 2,
+    memory_limit        BIGINT           DEFAULT 128000,
+    compare_mode        TEXT             DEFAULT 'exact',
+    float_tolerance     DOUBLE PRECISION DEFAULT 0.000001,
+    checker_language_id BIGINT,
+    checker_source      TEXT,
+    is_published        BOOLEAN          DEFAULT FALSE,
+    created_at          TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ,
+    CONSTRAINT uni_problems_slug UNIQUE (slug)
+);
+
+CREATE TABLE test_cases (
+    id              BIGSERIAL PRIMARY KEY,
+    problem_id      BIGINT  NOT NULL REFERENCES problems (id) ON DELETE CASCADE,
+    input           TEXT,
+    expected_output TEXT,
+    is_sample       BOOLEAN DEFAULT FALSE,
+    position        BIGINT  NOT NULL
+);
+CREATE INDEX idx_test_cases_problem_id ON test_cases (problem_id);
+
+CREATE TABLE problem_templates (
+    id            BIGSERIAL PRIMARY KEY,
+    problem_id    BIGINT NOT NULL REFERENCES problems (id) ON DELETE CASCADE,
+    language_slug TEXT   NOT NULL,
+    code          TEXT   NOT NULL
+);
+CREATE UNIQUE INDEX idx_problem_templates_problem_language ON problem_templates (problem_id, language_slug);
+
+CREATE TABLE submissions (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT      NOT NULL REFERENCES users (id),
+    problem_id  BIGINT      REFERENCES problems (id),
+    language_id BIGINT      NOT NULL,
+    source_hash VARCHAR(64) NOT NULL,
+    source_code TEXT        NOT NULL,
+    verdict     TEXT        NOT NULL,
+    time        DOUBLE PRECISION,
+    memory      BIGINT,
+    created_at  TIMESTAMPTZ
+);
+CREATE INDEX idx_submissions_user_id ON submissions (user_id);
+CREATE INDEX idx_submissions_problem_id ON submissions (problem_id);
+CREATE INDEX idx_submissions_source_hash ON submissions (source_hash);
+CREATE INDEX idx_submissions_verdict ON submissions (verdict);
+CREATE INDEX idx_submissions_created_at ON submissions (created_at);
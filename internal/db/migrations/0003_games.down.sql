@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS personal_bests;
+DROP TABLE IF EXISTS practice_sessions;
+DROP TABLE IF EXISTS match_participants;
+DROP TABLE IF EXISTS matches;
@@ -0,0 +1,45 @@
+CREATE TABLE matches (
+    id          BIGSERIAL PRIMARY KEY,
+    room_id     VARCHAR(36) NOT NULL,
+    problem_id  BIGINT      NOT NULL REFERENCES problems (id),
+    winner_id   BIGINT      REFERENCES users (id),
+    rated       BOOLEAN     NOT NULL DEFAULT FALSE,
+    started_at  TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_matches_problem_id ON matches (problem_id);
+CREATE INDEX idx_matches_winner_id ON matches (winner_id);
+CREATE INDEX idx_matches_finished_at ON matches (finished_at);
+
+CREATE TABLE match_participants (
+    id            BIGSERIAL PRIMARY KEY,
+    match_id      BIGINT  NOT NULL REFERENCES matches (id) ON DELETE CASCADE,
+    user_id       BIGINT  NOT NULL REFERENCES users (id),
+    language_id   BIGINT,
+    won           BOOLEAN NOT NULL DEFAULT FALSE,
+    rating_before BIGINT,
+    rating_after  BIGINT
+);
+CREATE INDEX idx_match_participants_match_id ON match_participants (match_id);
+CREATE INDEX idx_match_participants_user_id ON match_participants (user_id);
+
+CREATE TABLE practice_sessions (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    problem_id BIGINT      NOT NULL REFERENCES problems (id),
+    started_at TIMESTAMPTZ NOT NULL,
+    solved_at  TIMESTAMPTZ
+);
+CREATE INDEX idx_practice_sessions_user_id ON practice_sessions (user_id);
+CREATE INDEX idx_practice_sessions_problem_id ON practice_sessions (problem_id);
+
+CREATE TABLE personal_bests (
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT      NOT NULL REFERENCES users (id),
+    problem_id    BIGINT      NOT NULL REFERENCES problems (id),
+    submission_id BIGINT      NOT NULL REFERENCES submissions (id),
+    language_id   BIGINT      NOT NULL,
+    best_time_ms  BIGINT      NOT NULL,
+    achieved_at   TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX idx_personal_bests_user_problem ON personal_bests (user_id, problem_id);
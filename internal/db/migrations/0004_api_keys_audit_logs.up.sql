@@ -0,0 +1,30 @@
+CREATE TABLE api_keys (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT       NOT NULL REFERENCES users (id),
+    name         VARCHAR(64)  NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL,
+    scopes       VARCHAR(255) NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ
+);
+CREATE INDEX idx_api_keys_user_id ON api_keys (user_id);
+CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys (key_hash);
+
+-- actor_id 는 탈퇴한 계정도 남도록 외래 키를 걸지 않는다
+CREATE TABLE audit_logs (
+    id          BIGSERIAL PRIMARY KEY,
+    action      VARCHAR(64) NOT NULL,
+    actor_id    BIGINT,
+    target_type VARCHAR(32),
+    target_id   VARCHAR(64),
+    success     BOOLEAN     NOT NULL,
+    ip          VARCHAR(45),
+    user_agent  VARCHAR(512),
+    metadata    JSONB       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
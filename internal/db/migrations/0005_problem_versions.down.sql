@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS problem_versions;
+
+ALTER TABLE problems
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS reference_source,
+    DROP COLUMN IF EXISTS reference_language_id;
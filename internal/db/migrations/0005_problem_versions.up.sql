@@ -0,0 +1,14 @@
+ALTER TABLE problems
+    ADD COLUMN reference_language_id BIGINT,
+    ADD COLUMN reference_source      TEXT,
+    ADD COLUMN version               BIGINT NOT NULL DEFAULT 1;
+
+CREATE TABLE problem_versions (
+    id         BIGSERIAL PRIMARY KEY,
+    problem_id BIGINT NOT NULL REFERENCES problems (id) ON DELETE CASCADE,
+    version    BIGINT NOT NULL,
+    author_id  BIGINT REFERENCES users (id),
+    snapshot   JSONB  NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX idx_problem_versions_problem_version ON problem_versions (problem_id, version);
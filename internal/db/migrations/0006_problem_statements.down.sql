@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS problem_statements;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE problems DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,11 @@
+ALTER TABLE problems ADD COLUMN locale VARCHAR(8) NOT NULL DEFAULT 'en';
+ALTER TABLE users ADD COLUMN locale VARCHAR(8);
+
+CREATE TABLE problem_statements (
+    id         BIGSERIAL PRIMARY KEY,
+    problem_id BIGINT     NOT NULL REFERENCES problems (id) ON DELETE CASCADE,
+    locale     VARCHAR(8) NOT NULL,
+    title      TEXT       NOT NULL,
+    statement  TEXT       NOT NULL
+);
+CREATE UNIQUE INDEX idx_problem_statements_problem_locale ON problem_statements (problem_id, locale);
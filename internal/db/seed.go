@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/languages"
+)
+
+//go:embed seeds/problems.json
+var starterProblems []byte
+
+// SeedResult reports what Seed created.
+type SeedResult struct {
+	Problems int
+}
+
+// Seed creates the default roles and permissions and the starter problems.
+// It is safe to run on every start: existing rows are never modified.
+//
+// Languages are not stored in the database; they come from Judge0 and the
+// built-in definitions, which starter templates are checked against.
+func Seed(ctx context.Context, repository repositories.Repository) (*SeedResult, error) {
+	if err := repository.RoleRepository.Seed(ctx, models.DefaultRolePermissions); err != nil {
+		return nil, fmt.Errorf("failed to seed roles: %w", err)
+	}
+
+	problems, err := loadStarterProblems()
+	if err != nil {
+		return nil, err
+	}
+	created, err := repository.ProblemRepository.Seed(ctx, problems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed problems: %w", err)
+	}
+
+	return &SeedResult{Problems: created}, nil
+}
+
+func loadStarterProblems() ([]models.Problem, error) {
+	var problems []models.Problem
+	if err := json.Unmarshal(starterProblems, &problems); err != nil {
+		return nil, fmt.Errorf("invalid starter problems: %w", err)
+	}
+
+	for _, p := range problems {
+		if p.Slug == "" || p.Title == "" || len(p.TestCases) == 0 {
+			return nil, fmt.Errorf("starter problem %q needs a slug, a title and test cases", p.Slug)
+		}
+		for _, t := range p.Templates {
+			if _, ok := languages.BySlug(t.LanguageSlug); !ok {
+				return nil, fmt.Errorf("starter problem %q: unknown template language %q", p.Slug, t.LanguageSlug)
+			}
+		}
+	}
+	return problems, nil
+}
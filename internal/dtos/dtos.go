@@ -9,6 +9,15 @@ type CodeSubmissionRequest struct {
 	LanguageID     int    `json:"language_id"`
 	Stdin          string `json:"stdin,omitempty"`
 	ExpectedOutput string `json:"expected_output,omitempty"`
+	ProblemID      *uint  `json:"problem_id,omitempty"`
+}
+
+type SubmissionListQuery struct {
+	ProblemID  uint   `form:"problem_id"`
+	LanguageID int    `form:"language_id"`
+	Verdict    string `form:"verdict"`
+	Page       int    `form:"page"`
+	PerPage    int    `form:"per_page"`
 }
 
 type CodeSubmissionResponse struct {
@@ -26,6 +35,15 @@ type CodeSubmissionResult struct {
 	Memory string `json:"memory"`
 }
 
+type StartPracticeRequestDto struct {
+	ProblemID uint `json:"problem_id" binding:"required"`
+}
+
+type PracticeCodeRequestDto struct {
+	LanguageID int    `json:"language_id" binding:"required"`
+	SourceCode string `json:"source_code" binding:"required"`
+}
+
 type CreateGameRoomDto struct {
 	RoomName string `json:"room_name"`
 }
@@ -55,6 +73,123 @@ type SigninRequestDto struct {
 	Password string `json:"password"`
 }
 
+type RefreshRequestDto struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ResendVerificationRequestDto struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ForgotPasswordRequestDto struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordRequestDto struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ChangePasswordRequestDto struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type TwoFactorSigninRequestDto struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequestDto struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequestDto struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// DeleteAccountRequestDto re-authenticates an account deletion. Code is the
+// TOTP or recovery code, required only when 2FA is enabled.
+type DeleteAccountRequestDto struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code"`
+}
+
+// CreateAPIKeyRequestDto creates a personal API key. A key without
+// ExpiresInDays never expires.
+type CreateAPIKeyRequestDto struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays *int     `json:"expires_in_days"`
+}
+
+type RemovePlayerRequestDto struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Ban    bool   `json:"ban"`
+	Reason string `json:"reason"`
+}
+
+type ChangeRoleRequestDto struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type AuditLogListQuery struct {
+	ActorID uint      `form:"actor_id"`
+	Action  string    `form:"action"`
+	Since   time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until   time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page    int       `form:"page"`
+	PerPage int       `form:"per_page"`
+}
+
+type ProblemTestCaseDto struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+	IsSample       bool   `json:"is_sample"`
+}
+
+// ProblemStatementDto is a translation of the title and statement.
+type ProblemStatementDto struct {
+	Locale    string `json:"locale" binding:"required"`
+	Title     string `json:"title" binding:"required"`
+	Statement string `json:"statement"`
+}
+
+// ProblemRequestDto creates or replaces a problem. When editing, Version is
+// the version the author started from; saving fails if it changed since.
+type ProblemRequestDto struct {
+	Version             int                   `json:"version"`
+	Slug                string                `json:"slug" binding:"required"`
+	Title               string                `json:"title" binding:"required"`
+	Statement           string                `json:"statement"` // Markdown
+	Locale              string                `json:"locale"`    // Title, Statement 의 언어 (기본 en)
+	Statements          []ProblemStatementDto `json:"statements"`
+	Difficulty          string                `json:"difficulty"`
+	TimeLimit           float64               `json:"time_limit"`
+	MemoryLimit         int                   `json:"memory_limit"`
+	CompareMode         string                `json:"compare_mode"`
+	FloatTolerance      float64               `json:"float_tolerance"`
+	CheckerLanguageID   int                   `json:"checker_language_id"`
+	CheckerSource       string                `json:"checker_source"`
+	ReferenceLanguageID int                   `json:"reference_language_id"`
+	ReferenceSource     string                `json:"reference_source"`
+	TestCases           []ProblemTestCaseDto  `json:"test_cases"`
+	Templates           map[string]string     `json:"templates"` // 언어 slug -> 코드
+}
+
+// UpdateProfileRequestDto is a partial update: omitted fields are left
+// unchanged and an empty string clears an optional field.
+type UpdateProfileRequestDto struct {
+	Username          *string `json:"username"`
+	DisplayName       *string `json:"display_name"`
+	Bio               *string `json:"bio"`
+	AvatarURL         *string `json:"avatar_url"`
+	PreferredLanguage *string `json:"preferred_language"`
+	EditorTheme       *string `json:"editor_theme"`
+	Locale            *string `json:"locale"`
+}
+
 type SigninResponseDto struct {
 	user  UserResponseDto
 	token string
@@ -69,7 +204,7 @@ type UserResponseDto struct {
 }
 
 type SignupRequestDto struct {
-	Username string `json:"user_name" validate:"required,min=3,max=32"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password"`
+	Username string `json:"user_name" binding:"required,min=3,max=32"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
 }
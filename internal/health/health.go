@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDisabled = "disabled"
+	StatusDraining = "draining"
+
+	// 응답이 없는 의존성 하나 때문에 프로브 전체가 타임아웃 나지 않도록
+	checkTimeout = 2 * time.Second
+)
+
+// Check is one dependency. Run returns nil when the dependency is usable.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+	// CacheFor reuses the last result for this long, for dependencies that
+	// are slow or rate limited (e.g. Judge0 on RapidAPI). 0 checks every time.
+	CacheFor time.Duration
+	// Disabled checks are reported but never run and never fail.
+	Disabled bool
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string    `json:"status"`
+	LatencyMs float64   `json:"latencyMs"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Cached    bool      `json:"cached,omitempty"`
+}
+
+// Report is the body of /readyz and /livez.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// OK reports whether the instance should receive traffic.
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+type cachedCheck struct {
+	Check
+	mu   sync.Mutex
+	last Result
+}
+
+// Checker runs the readiness and liveness checks of the API. Readiness
+// covers everything a request may need; liveness only what a restart would
+// fix, so an outage of Postgres does not get every instance killed.
+type Checker struct {
+	readiness []*cachedCheck
+	liveness  []*cachedCheck
+	draining  atomic.Bool
+	startedAt time.Time
+}
+
+func NewChecker() *Checker {
+	return &Checker{startedAt: time.Now()}
+}
+
+// AddReadiness registers a check run by Ready.
+func (h *Checker) AddReadiness(c Check) {
+	h.readiness = append(h.readiness, &cachedCheck{Check: c})
+}
+
+// AddLiveness registers a check run by Live.
+func (h *Checker) AddLiveness(c Check) {
+	h.liveness = append(h.liveness, &cachedCheck{Check: c})
+}
+
+// Drain makes Ready fail from now on so load balancers stop sending new
+// requests while the server shuts down. It is safe to call on a nil Checker.
+func (h *Checker) Drain() {
+	if h != nil {
+		h.draining.Store(true)
+	}
+}
+
+func (h *Checker) Uptime() time.Duration {
+	return time.Since(h.startedAt)
+}
+
+// Ready runs the readiness checks concurrently.
+func (h *Checker) Ready(ctx context.Context) Report {
+	report := run(ctx, h.readiness)
+	if h.draining.Load() {
+		report.Status = StatusDraining
+	}
+	return report
+}
+
+// Live runs the liveness checks concurrently.
+func (h *Checker) Live(ctx context.Context) Report {
+	return run(ctx, h.liveness)
+}
+
+func run(ctx context.Context, checks []*cachedCheck) Report {
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *cachedCheck) {
+			defer wg.Done()
+			results[i] = c.result(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.Name] = results[i]
+		if results[i].Status == StatusFail {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func (c *cachedCheck) result(ctx context.Context) Result {
+	if c.Disabled {
+		return Result{Status: StatusDisabled, CheckedAt: time.Now()}
+	}
+
+	// 캐시가 만료되었을 때 동시에 들어온 프로브는 한 번만 확인하고 결과를 공유
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.CacheFor > 0 && !c.last.CheckedAt.IsZero() && time.Since(c.last.CheckedAt) < c.CacheFor {
+		cached := c.last
+		cached.Cached = true
+		return cached
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Run(ctx)
+	result := Result{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	c.last = result
+	return result
+}
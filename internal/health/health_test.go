@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func ok(context.Context) error   { return nil }
+func fail(context.Context) error { return errors.New("connection refused") }
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []Check
+		drain      bool
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{"no checks", nil, false, StatusOK, map[string]string{}},
+		{"all ok", []Check{{Name: "postgres", Run: ok}, {Name: "redis", Run: ok}}, false, StatusOK,
+			map[string]string{"postgres": StatusOK, "redis": StatusOK}},
+		{"one failing", []Check{{Name: "postgres", Run: ok}, {Name: "redis", Run: fail}}, false, StatusFail,
+			map[string]string{"postgres": StatusOK, "redis": StatusFail}},
+		// 꺼진 의존성은 실행하지 않고 실패로 치지 않음
+		{"disabled", []Check{{Name: "postgres", Run: ok}, {Name: "redis", Run: fail, Disabled: true}}, false, StatusOK,
+			map[string]string{"postgres": StatusOK, "redis": StatusDisabled}},
+		// 종료 중에는 의존성 상태와 관계없이 draining
+		{"draining", []Check{{Name: "postgres", Run: ok}}, true, StatusDraining,
+			map[string]string{"postgres": StatusOK}},
+		{"draining with a failure", []Check{{Name: "postgres", Run: fail}}, true, StatusDraining,
+			map[string]string{"postgres": StatusFail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewChecker()
+			for _, c := range tt.checks {
+				h.AddReadiness(c)
+			}
+			if tt.drain {
+				h.Drain()
+			}
+
+			report := h.Ready(context.Background())
+			if report.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", report.Status, tt.wantStatus)
+			}
+			if report.OK() != (tt.wantStatus == StatusOK) {
+				t.Errorf("OK() = %v with status %s", report.OK(), report.Status)
+			}
+			if len(report.Checks) != len(tt.wantChecks) {
+				t.Errorf("checks = %v, want %v", report.Checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				if got := report.Checks[name].Status; got != want {
+					t.Errorf("check %s = %s, want %s", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Drain 은 readiness 에만 영향을 줌 (liveness 가 실패하면 종료 전에 재시작됨)
+func TestDrainKeepsLiveness(t *testing.T) {
+	h := NewChecker()
+	h.AddReadiness(Check{Name: "postgres", Run: ok})
+	h.AddLiveness(Check{Name: "game_manager", Run: ok})
+
+	if report := h.Ready(context.Background()); !report.OK() {
+		t.Fatalf("Ready before Drain = %s", report.Status)
+	}
+	h.Drain()
+	if report := h.Ready(context.Background()); report.Status != StatusDraining {
+		t.Errorf("Ready after Drain = %s, want %s", report.Status, StatusDraining)
+	}
+	if report := h.Live(context.Background()); !report.OK() {
+		t.Errorf("Live after Drain = %s, want %s", report.Status, StatusOK)
+	}
+
+	var nilChecker *Checker
+	nilChecker.Drain()
+}
+
+func TestCheckCache(t *testing.T) {
+	var runs atomic.Int32
+	counting := func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		cacheFor time.Duration
+		wantRuns int32
+	}{
+		{"no cache", 0, 3},
+		{"cached", time.Minute, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs.Store(0)
+			h := NewChecker()
+			h.AddReadiness(Check{Name: "judge0", Run: counting, CacheFor: tt.cacheFor})
+
+			var last Report
+			for i := 0; i < 3; i++ {
+				last = h.Ready(context.Background())
+			}
+			if got := runs.Load(); got != tt.wantRuns {
+				t.Errorf("check ran %d times, want %d", got, tt.wantRuns)
+			}
+			if cached := last.Checks["judge0"].Cached; cached != (tt.cacheFor > 0) {
+				t.Errorf("last result cached = %v", cached)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer logs messages instead of sending them, optionally keeping a copy
+// of each one on disk.
+type LogMailer struct {
+	from   string
+	dir    string
+	logger *zap.SugaredLogger
+}
+
+func NewLogMailer(from, dir string, logger *zap.SugaredLogger) *LogMailer {
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+	return &LogMailer{from: from, dir: dir, logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.Infow("mail (not sent)", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+
+	if m.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg), 0o644)
+}
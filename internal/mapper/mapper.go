@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
@@ -11,15 +12,186 @@ type MappedUser struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	RoleID    uint      `json:"role_id"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
+
+	DisplayName       string `json:"display_name"`
+	Bio               string `json:"bio"`
+	AvatarURL         string `json:"avatar_url"`
+	PreferredLanguage string `json:"preferred_language"`
+	EditorTheme       string `json:"editor_theme"`
+	Locale            string `json:"locale"`
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
 }
 
 func UserMapper(u *models.User) *MappedUser {
-	return &MappedUser{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		RoleID:    u.RoleID,
-		CreatedAt: u.CreatedAt,
+	mapped := &MappedUser{
+		ID:                u.ID,
+		Username:          u.Username,
+		Email:             u.Email,
+		RoleID:            u.RoleID,
+		CreatedAt:         u.CreatedAt,
+		DisplayName:       u.DisplayName,
+		Bio:               u.Bio,
+		AvatarURL:         u.AvatarURL,
+		PreferredLanguage: u.PreferredLanguage,
+		EditorTheme:       u.EditorTheme,
+		Locale:            u.Locale,
+
+		DeletionScheduledAt: u.DeletionScheduledAt,
+	}
+	if u.Role != nil {
+		mapped.Role = u.Role.Name
+	}
+	return mapped
+}
+
+type MappedLanguage struct {
+	ID   int    `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type MappedUserStats struct {
+	MatchesPlayed     int64           `json:"matches_played"`
+	Wins              int64           `json:"wins"`
+	Rating            int             `json:"rating"`
+	FavouriteLanguage *MappedLanguage `json:"favourite_language"`
+}
+
+// MappedPublicProfile is what other users can see. It never includes the
+// email address.
+type MappedPublicProfile struct {
+	Username    string          `json:"username"`
+	DisplayName string          `json:"display_name"`
+	Bio         string          `json:"bio"`
+	AvatarURL   string          `json:"avatar_url"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Stats       MappedUserStats `json:"stats"`
+}
+
+func PublicProfileMapper(u *models.User) *MappedPublicProfile {
+	return &MappedPublicProfile{
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarURL:   u.AvatarURL,
+		CreatedAt:   u.CreatedAt,
+		Stats:       MappedUserStats{Rating: u.Rating},
+	}
+}
+
+// MappedAPIKey describes a key without the key itself, which is only shown
+// once when it is created.
+type MappedAPIKey struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func APIKeyMapper(k *models.APIKey) *MappedAPIKey {
+	return &MappedAPIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.ScopeList(),
+		LastUsedAt: k.LastUsedAt,
+		ExpiresAt:  k.ExpiresAt,
+		CreatedAt:  k.CreatedAt,
 	}
 }
+
+type MappedAuditLog struct {
+	ID         uint            `json:"id"`
+	Action     string          `json:"action"`
+	ActorID    *uint           `json:"actor_id,omitempty"`
+	TargetType string          `json:"target_type,omitempty"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Success    bool            `json:"success"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	Metadata   json.RawMessage `json:"metadata"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func AuditLogMapper(l *models.AuditLog) *MappedAuditLog {
+	metadata := json.RawMessage(l.Metadata)
+	if !json.Valid(metadata) {
+		metadata = json.RawMessage("{}")
+	}
+	return &MappedAuditLog{
+		ID:         l.ID,
+		Action:     l.Action,
+		ActorID:    l.ActorID,
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		Success:    l.Success,
+		IP:         l.IP,
+		UserAgent:  l.UserAgent,
+		Metadata:   metadata,
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+type MappedSample struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+// MappedProblem is the public view of a problem: hidden test cases and the
+// checker are never included. Title and statement are in Locale, the best
+// match for the reader among Locales.
+type MappedProblem struct {
+	ID            uint               `json:"id"`
+	Slug          string             `json:"slug"`
+	Title         string             `json:"title"`
+	Statement     string             `json:"statement,omitempty"`      // Markdown
+	StatementHTML string             `json:"statement_html,omitempty"` // 서버에서 변환, 정제한 HTML
+	Locale        string             `json:"locale"`
+	Locales       []string           `json:"locales"`
+	Difficulty    string             `json:"difficulty"`
+	TimeLimit     float64            `json:"time_limit"`
+	MemoryLimit   int                `json:"memory_limit"`
+	CompareMode   models.CompareMode `json:"compare_mode"`
+	Samples       []MappedSample     `json:"samples,omitempty"`
+	Templates     map[string]string  `json:"templates,omitempty"`
+}
+
+// ProblemMapper maps the problem in the first of the preferred locales it is
+// written in.
+func ProblemMapper(p *models.Problem, locales []string) *MappedProblem {
+	locale, title, statement := p.Localized(locales)
+	mapped := &MappedProblem{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Title:       title,
+		Statement:   statement,
+		Locale:      locale,
+		Locales:     p.Locales(),
+		Difficulty:  p.Difficulty,
+		TimeLimit:   p.TimeLimit,
+		MemoryLimit: p.MemoryLimit,
+		CompareMode: p.CompareMode,
+	}
+
+	for _, tc := range p.Samples() {
+		mapped.Samples = append(mapped.Samples, MappedSample{
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+		})
+	}
+
+	if len(p.Templates) > 0 {
+		mapped.Templates = make(map[string]string, len(p.Templates))
+		for _, t := range p.Templates {
+			mapped.Templates[t.LanguageSlug] = t.Code
+		}
+	}
+
+	return mapped
+}
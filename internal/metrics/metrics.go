@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "code_racer"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	WebSocketConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "websocket",
+		Name:      "connections_active",
+		Help:      "Game WebSocket connections currently open.",
+	})
+
+	WebSocketMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "websocket",
+		Name:      "messages_total",
+		Help:      "Game WebSocket messages by direction (in, out) and type.",
+	}, []string{"direction", "type"})
+
+	WebSocketDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "websocket",
+		Name:      "messages_dropped_total",
+		Help:      "Messages dropped because the player's send buffer was full.",
+	}, []string{"type"})
+
+	MatchesStarted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "game",
+		Name:      "matches_started_total",
+		Help:      "Games that started.",
+	})
+
+	MatchesFinished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "game",
+		Name:      "matches_finished_total",
+		Help:      "Games that ended with a winner.",
+	})
+
+	Judge0RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "judge0",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of Judge0 submissions by outcome (ok, error).",
+		// wait=true 로 실행이 끝날 때까지 기다리므로 HTTP 기본 버킷보다 길게
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"outcome"})
+
+	JudgeVerdicts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "judge",
+		Name:      "verdicts_total",
+		Help:      "Judged submissions by verdict.",
+	}, []string{"verdict"})
+)
+
+// RegisterRooms exposes the live rooms by status, counted by rooms at
+// scrape time.
+func RegisterRooms(rooms func() map[string]int) {
+	prometheus.MustRegister(&roomsCollector{rooms: rooms})
+}
+
+var roomsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "game", "rooms"),
+	"Live game rooms by status.",
+	[]string{"status"}, nil,
+)
+
+type roomsCollector struct {
+	rooms func() map[string]int
+}
+
+func (c *roomsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- roomsDesc
+}
+
+func (c *roomsCollector) Collect(ch chan<- prometheus.Metric) {
+	for status, count := range c.rooms() {
+		ch <- prometheus.MustNewConstMetric(roomsDesc, prometheus.GaugeValue, float64(count), status)
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDBPool exposes the connection pool stats of the Postgres pool.
+func RegisterDBPool(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// RegisterRedisPool exposes the connection pool stats of rdb.
+func RegisterRedisPool(rdb *redis.Client) {
+	prometheus.MustRegister(&redisPoolCollector{rdb: rdb})
+}
+
+var (
+	redisHitsDesc     = redisPoolDesc("hits_total", "Times a free connection was found in the pool.")
+	redisMissesDesc   = redisPoolDesc("misses_total", "Times a free connection was not found in the pool.")
+	redisTimeoutsDesc = redisPoolDesc("timeouts_total", "Times waiting for a connection timed out.")
+	redisTotalDesc    = redisPoolDesc("connections", "Connections in the pool.")
+	redisIdleDesc     = redisPoolDesc("idle_connections", "Idle connections in the pool.")
+	redisStaleDesc    = redisPoolDesc("stale_connections_total", "Stale connections removed from the pool.")
+)
+
+func redisPoolDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "redis_pool", name), help, nil, nil)
+}
+
+type redisPoolCollector struct {
+	rdb *redis.Client
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisHitsDesc
+	ch <- redisMissesDesc
+	ch <- redisTimeoutsDesc
+	ch <- redisTotalDesc
+	ch <- redisIdleDesc
+	ch <- redisStaleDesc
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.rdb.PoolStats()
+	ch <- prometheus.MustNewConstMetric(redisHitsDesc, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(redisMissesDesc, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(redisTimeoutsDesc, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisTotalDesc, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisIdleDesc, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(redisStaleDesc, prometheus.CounterValue, float64(stats.StaleConns))
+}
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/services/apikeys"
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+var (
+	errAPIKeyNotAllowed = errors.New("API keys are not accepted on this endpoint")
+	errAPIKeyScope      = errors.New("API key is missing the required scope")
+)
+
+// authenticateAPIKey resolves the key to its owner. The request continues
+// with the owner as "user" and the key in "api_key"; there is no session.
+func authenticateAPIKey(app *config.Application, c *gin.Context, key string, scopes []string) {
+	if len(scopes) == 0 {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": errAPIKeyNotAllowed.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	as := apikeys.NewAPIKeysService(app.Repository.APIKeyRepository, app.Logger)
+	apiKey, err := as.Authenticate(ctx, key)
+	if err != nil {
+		if !errors.Is(err, apikeys.ErrInvalidAPIKey) {
+			app.Logger.Errorw("failed to authenticate api key", "error", err)
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired API key"})
+		return
+	}
+
+	allowed := false
+	for _, scope := range scopes {
+		if apiKey.HasScope(scope) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": errAPIKeyScope.Error()})
+		return
+	}
+
+	// 탈퇴 유예 중인 계정의 키는 사용할 수 없음
+	user, err := getUser(app, ctx, int(apiKey.UserID))
+	if err != nil || user.DeletionScheduledAt != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errUserNotLoaded.Error()})
+		return
+	}
+
+	c.Set("user", user)
+	c.Set("api_key", apiKey)
+	c.Next()
+}
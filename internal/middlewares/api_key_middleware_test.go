@@ -0,0 +1,122 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type fakeAPIKeyRepository struct {
+	repositories.APIKeyRepositoryInterface
+	keys map[string]*models.APIKey // 해시 기준
+}
+
+func (r *fakeAPIKeyRepository) GetByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	key, ok := r.keys[hash]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *key
+	return &copied, nil
+}
+
+func (r *fakeAPIKeyRepository) TouchLastUsed(context.Context, uint, time.Time) error { return nil }
+
+type fakeUserRepository struct {
+	repositories.UserRepositoryInterface
+	users map[int]*models.User
+}
+
+func (r *fakeUserRepository) GetByID(_ context.Context, id int) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	return user, nil
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	past := time.Now().Add(-time.Hour)
+	keys := map[string]*models.APIKey{
+		"crk_problems": {ID: 1, UserID: 1, Scopes: models.ScopeProblemsRead},
+		"crk_games":    {ID: 2, UserID: 1, Scopes: models.ScopeGamesRead},
+		"crk_both":     {ID: 3, UserID: 1, Scopes: models.ScopeGamesRead + " " + models.ScopeProblemsRead},
+		"crk_expired":  {ID: 4, UserID: 1, Scopes: models.ScopeProblemsRead, ExpiresAt: &past},
+		"crk_leaving":  {ID: 5, UserID: 2, Scopes: models.ScopeProblemsRead},
+	}
+	byHash := make(map[string]*models.APIKey, len(keys))
+	for key, apiKey := range keys {
+		byHash[utils.HashToken(key)] = apiKey
+	}
+
+	app := &config.Application{
+		Repository: repositories.Repository{
+			APIKeyRepository: &fakeAPIKeyRepository{keys: byHash},
+			UserRepository: &fakeUserRepository{users: map[int]*models.User{
+				1: {ID: 1, Username: "racer"},
+				2: {ID: 2, Username: "leaving", DeletionScheduledAt: &past},
+			}},
+		},
+		Config: &config.Config{},
+		Logger: zap.NewNop().Sugar(),
+	}
+
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		user, _ := CurrentUser(c)
+		c.String(http.StatusOK, user.Username)
+	}
+	router.GET("/problems", AuthMiddleware(app, models.ScopeProblemsRead), handler)
+	router.GET("/either", AuthMiddleware(app, models.ScopeGamesRead, models.ScopeCodeSubmit), handler)
+	router.GET("/profile", AuthMiddleware(app), handler)
+
+	tests := []struct {
+		name      string
+		path      string
+		key       string
+		wantCode  int
+		wantError string
+	}{
+		{"granted scope", "/problems", "crk_problems", http.StatusOK, ""},
+		{"one of several scopes", "/problems", "crk_both", http.StatusOK, ""},
+		{"any listed scope", "/either", "crk_games", http.StatusOK, ""},
+		{"missing scope", "/problems", "crk_games", http.StatusForbidden, errAPIKeyScope.Error()},
+		{"missing every listed scope", "/either", "crk_problems", http.StatusForbidden, errAPIKeyScope.Error()},
+		{"session-only route", "/profile", "crk_both", http.StatusForbidden, errAPIKeyNotAllowed.Error()},
+		{"unknown key", "/problems", "crk_unknown", http.StatusUnauthorized, "Invalid or expired API key"},
+		{"not an api key", "/problems", "problems", http.StatusUnauthorized, "Invalid or expired API key"},
+		{"expired key", "/problems", "crk_expired", http.StatusUnauthorized, "Invalid or expired API key"},
+		{"account being deleted", "/problems", "crk_leaving", http.StatusUnauthorized, errUserNotLoaded.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(apiKeyHeader, tt.key)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantError != "" && !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want error %q", w.Body.String(), tt.wantError)
+			}
+			if tt.wantCode == http.StatusOK && w.Body.String() != "racer" {
+				t.Errorf("handler saw user %q, want the key owner", w.Body.String())
+			}
+		})
+	}
+}
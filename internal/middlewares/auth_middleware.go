@@ -2,63 +2,189 @@ package middlewares
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/config"
-	"github.com/Dongmoon29/code_racer_api/internal/env"
 	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/i18n"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(app *config.Application) gin.HandlerFunc {
+// 인증 실패 시 응답 메시지
+var (
+	errTokenMissing   = errors.New("Token is missing")
+	errInvalidToken   = errors.New("Invalid or expired token")
+	errInvalidClaims  = errors.New("Invalid token claims")
+	errSessionRevoked = errors.New("Session has been revoked")
+	errUserNotLoaded  = errors.New("Failed to load user")
+)
+
+// AuthMiddleware authenticates the request with an access token. When scopes
+// are given, an API key in the X-API-Key header is accepted as well if it was
+// granted at least one of them; routes without scopes reject API keys.
+func AuthMiddleware(app *config.Application, scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := c.GetHeader(apiKeyHeader); key != "" {
+			authenticateAPIKey(app, c, key, scopes)
+			return
+		}
 
-		// 요청 정보 출력
-		fmt.Printf("URL: %s\n", c.Request.URL.Path)
-		fmt.Printf("Method: %s\n", c.Request.Method)
-		fmt.Printf("Headers: %v\n", c.Request.Header)
+		token := tokenFromRequest(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errTokenMissing.Error()})
+			return
+		}
 
-		token, err := c.Cookie("token")
+		user, sessionID, err := authenticateToken(app, c.Request.Context(), token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token is missing"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
 
-		secretKey := env.GetString("JWT_SECRET", "secret")
-		jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secretKey), nil
-		})
-		if err != nil || !jwtToken.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		c.Set("user", user)
+		c.Set("session_id", sessionID)
+		c.Next()
+	}
+}
+
+// WebSocketAuthMiddleware authenticates the WebSocket handshake. Browsers
+// cannot set headers on a cross-origin WebSocket, so besides the regular
+// token a single-use ticket from POST /games/ws-ticket is accepted in the
+// "ticket" query parameter.
+func WebSocketAuthMiddleware(app *config.Application) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			AuthMiddleware(app)(c)
 			return
 		}
 
-		claims, ok := jwtToken.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		ctx := c.Request.Context()
+		value, ok, err := app.CacheStorage.Tickets.Consume(ctx, cache.TicketWebSocket, utils.HashToken(ticket))
+		if err != nil || !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
 			return
 		}
 
-		userID, err := strconv.Atoi(claims["user_id"].(string))
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		userIDStr, sessionID, found := strings.Cut(value, ":")
+		userID, err := strconv.Atoi(userIDStr)
+		if !found || err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
 			return
 		}
 
-		user, err := getUser(app, c.Request.Context(), userID)
+		user, err := authenticateSession(app, ctx, userID, sessionID)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Failed to load user"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
 
 		c.Set("user", user)
+		c.Set("session_id", sessionID)
 		c.Next()
 	}
 }
 
+// tokenFromRequest reads the access token from the Authorization header,
+// falling back to the "token" cookie set by the web client.
+func tokenFromRequest(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	token, err := c.Cookie("token")
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+func authenticateToken(app *config.Application, ctx context.Context, token string) (*mapper.MappedUser, string, error) {
+	claims, err := utils.ParseAccessToken(token)
+	if err != nil {
+		return nil, "", errInvalidToken
+	}
+
+	userID, err := strconv.Atoi(claims.UserID)
+	if err != nil {
+		return nil, "", errInvalidClaims
+	}
+
+	user, err := authenticateSession(app, ctx, userID, claims.SessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, claims.SessionID, nil
+}
+
+func authenticateSession(app *config.Application, ctx context.Context, userID int, sessionID string) (*mapper.MappedUser, error) {
+	active, err := isSessionActive(app, ctx, sessionID)
+	if err != nil || !active {
+		return nil, errSessionRevoked
+	}
+
+	user, err := getUser(app, ctx, userID)
+	if err != nil {
+		return nil, errUserNotLoaded
+	}
+	return user, nil
+}
+
+// isSessionActive checks the session behind an access token, using the Redis
+// cache when enabled and falling back to Postgres on a miss.
+func isSessionActive(app *config.Application, ctx context.Context, sessionID string) (bool, error) {
+	if app.Config.RedisConfig.Enabled {
+		active, found, err := app.CacheStorage.Sessions.Get(ctx, sessionID)
+		if err == nil && found {
+			return active, nil
+		}
+	}
+
+	session, err := app.Repository.SessionRepository.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	active := session.IsActive(time.Now())
+	if app.Config.RedisConfig.Enabled {
+		_ = app.CacheStorage.Sessions.Set(ctx, sessionID, active, utils.AccessTokenTTL)
+	}
+	return active, nil
+}
+
+// CurrentUser returns the user stored in the context by AuthMiddleware.
+func CurrentUser(c *gin.Context) (*mapper.MappedUser, bool) {
+	userData, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := userData.(*mapper.MappedUser)
+	return user, ok && user != nil
+}
+
+// PreferredLocales returns the locales to show content in: the signed-in
+// user's saved locale, then the Accept-Language header.
+func PreferredLocales(c *gin.Context) []string {
+	saved := ""
+	if user, ok := CurrentUser(c); ok {
+		saved = user.Locale
+	}
+	return i18n.Preferred(saved, c.GetHeader("Accept-Language"))
+}
+
 func getUser(app *config.Application, ctx context.Context, userID int) (*mapper.MappedUser, error) {
 	if !app.Config.RedisConfig.Enabled {
 		return getUserFromDB(app, ctx, userID)
@@ -80,6 +206,7 @@ func getUser(app *config.Application, ctx context.Context, userID int) (*mapper.
 		if err := app.CacheStorage.Users.Set(ctx, mappedUser); err != nil {
 			return nil, err
 		}
+		return mappedUser, nil
 	}
 
 	return user, nil
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission allows the request only if the user's role grants every
+// listed permission. It must run after AuthMiddleware.
+func RequirePermission(app *config.Application, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: Please login"})
+			return
+		}
+
+		role, err := app.Repository.RoleRepository.GetByID(c.Request.Context(), user.RoleID)
+		if err != nil {
+			app.Logger.Errorw("failed to load role for permission check", "userID", user.ID, "error", err)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+
+		for _, permission := range permissions {
+			if !role.HasPermission(permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,151 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/config"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware applies the token buckets configured for route in
+// app.Config.RateLimits. The per-user bucket is only used when the request is
+// authenticated, so the middleware must run after AuthMiddleware to take it
+// into account. The IP bucket is checked first so a request it rejects does
+// not use up the user's tokens. A route without configuration is not
+// limited.
+func RateLimitMiddleware(app *config.Application, route string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := app.Config.RateLimits[route]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		var results []*cache.RateLimitResult
+
+		if rule.PerIP.Requests > 0 {
+			key := fmt.Sprintf("%s:ip:%s", route, c.ClientIP())
+			result, err := app.CacheStorage.RateLimits.Take(ctx, key, rule.PerIP.Requests, rule.PerIP.Per)
+			if err != nil {
+				app.Logger.Errorw("rate limit check failed", "route", route, "error", err)
+				c.Next()
+				return
+			}
+			results = append(results, result)
+		}
+
+		user, ok := CurrentUser(c)
+		ipAllowed := len(results) == 0 || results[0].Allowed
+		if ok && ipAllowed && rule.PerUser.Requests > 0 {
+			key := fmt.Sprintf("%s:user:%d", route, user.ID)
+			result, err := app.CacheStorage.RateLimits.Take(ctx, key, rule.PerUser.Requests, rule.PerUser.Per)
+			if err != nil {
+				app.Logger.Errorw("rate limit check failed", "route", route, "error", err)
+				c.Next()
+				return
+			}
+			results = append(results, result)
+		}
+
+		if len(results) == 0 {
+			c.Next()
+			return
+		}
+
+		result := mostRestrictive(results)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// DailyQuotaMiddleware counts requests per user and UTC day against the limit
+// configured for the user's role, taken from the user AuthMiddleware loaded.
+// Roles without an entry, or with a limit of zero, are not limited. It must
+// run after AuthMiddleware.
+func DailyQuotaMiddleware(app *config.Application, name string, quotas map[string]int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		roleName := user.Role
+		if roleName == "" {
+			// 역할 이름이 없는 예전 캐시 항목만 DB 에서 조회
+			role, err := app.Repository.RoleRepository.GetByID(ctx, user.RoleID)
+			if err != nil {
+				app.Logger.Errorw("failed to load role for quota", "userID", user.ID, "error", err)
+				c.Next()
+				return
+			}
+			roleName = role.Name
+		}
+
+		limit := quotas[roleName]
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now().UTC()
+		endOfDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		key := fmt.Sprintf("%s:%d:%s", name, user.ID, now.Format("20060102"))
+
+		used, err := app.CacheStorage.RateLimits.Incr(ctx, key, endOfDay)
+		if err != nil {
+			app.Logger.Errorw("quota check failed", "quota", name, "error", err)
+			c.Next()
+			return
+		}
+
+		remaining := int64(limit) - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-Quota-Limit", strconv.Itoa(limit))
+		c.Header("X-Quota-Remaining", strconv.FormatInt(remaining, 10))
+
+		if used > int64(limit) {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(endOfDay.Sub(now))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Daily quota exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func mostRestrictive(results []*cache.RateLimitResult) *cache.RateLimitResult {
+	selected := results[0]
+	for _, result := range results[1:] {
+		if !result.Allowed && selected.Allowed {
+			selected = result
+			continue
+		}
+		if result.Allowed == selected.Allowed && result.Remaining < selected.Remaining {
+			selected = result
+		}
+	}
+	return selected
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
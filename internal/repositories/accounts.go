@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+// ScheduleDeletion marks the account for deletion at the given time.
+func (s *UserRepositoryImpl) ScheduleDeletion(ctx context.Context, userID uint, at time.Time) error {
+	result := s.DB.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("deletion_scheduled_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CancelDeletion clears a pending deletion. It reports whether one was
+// pending.
+func (s *UserRepositoryImpl) CancelDeletion(ctx context.Context, userID uint) (bool, error) {
+	result := s.DB.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND deletion_scheduled_at IS NOT NULL", userID).
+		Update("deletion_scheduled_at", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListDueForDeletion returns the ids of accounts whose grace period ended
+// before t.
+func (s *UserRepositoryImpl) ListDueForDeletion(ctx context.Context, t time.Time) ([]uint, error) {
+	var ids []uint
+	err := s.DB.WithContext(ctx).Model(&models.User{}).
+		Where("deletion_scheduled_at <= ?", t).
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Delete anonymizes the account instead of removing the row, so matches,
+// ratings and submission statistics of other users stay consistent. Personal
+// data and credentials are erased and the row is soft-deleted.
+func (s *UserRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"username":              fmt.Sprintf("deleted-%d", id),
+				"email":                 fmt.Sprintf("deleted-%d@users.invalid", id),
+				"password":              "",
+				"display_name":          "",
+				"bio":                   "",
+				"avatar_url":            "",
+				"preferred_language":    "",
+				"editor_theme":          "",
+				"is_active":             false,
+				"deletion_scheduled_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		// 로그인 수단과 토큰 삭제
+		personal := []interface{}{
+			&models.UserIdentity{},
+			&models.UserTOTP{},
+			&models.RecoveryCode{},
+			&models.PasswordResetToken{},
+			&models.APIKey{},
+		}
+		for _, model := range personal {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		sessions := tx.Model(&models.AuthSession{}).Select("id").Where("user_id = ?", id)
+		if err := tx.Where("session_id IN (?)", sessions).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.AuthSession{}).Error; err != nil {
+			return err
+		}
+
+		// 제출 기록은 통계를 위해 남기고 소스 코드만 삭제
+		err := tx.Model(&models.Submission{}).
+			Where("user_id = ?", id).
+			Update("source_code", "").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.User{}, id).Error
+	})
+}
+
+// ListIdentities returns the external accounts linked to the user.
+func (s *UserRepositoryImpl) ListIdentities(ctx context.Context, userID uint) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	err := s.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&identities).Error
+	return identities, err
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingConnPool 는 DB 없이 GORM 이 만든 SQL 을 기록 (모든 쓰기는 한 행에 적용된 것으로 처리)
+type recordingConnPool struct {
+	mu         sync.Mutex
+	statements []string
+	committed  bool
+}
+
+func (p *recordingConnPool) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statements = append(p.statements, query)
+	return rowsAffected(1), nil
+}
+
+func (p *recordingConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *recordingConnPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *recordingConnPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *recordingConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return p, nil
+}
+
+func (p *recordingConnPool) Commit() error {
+	p.committed = true
+	return nil
+}
+
+func (p *recordingConnPool) Rollback() error { return nil }
+
+type rowsAffected int64
+
+func (r rowsAffected) LastInsertId() (int64, error) { return 0, nil }
+func (r rowsAffected) RowsAffected() (int64, error) { return int64(r), nil }
+
+func newRecordingDB(t *testing.T) (*gorm.DB, *recordingConnPool) {
+	t.Helper()
+	pool := &recordingConnPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, pool
+}
+
+// 익명화는 로그인 수단(외부 계정, TOTP, 복구 코드, API 키)과 세션을 모두 지움
+func TestDeleteUserClearsCredentials(t *testing.T) {
+	db, pool := newRecordingDB(t)
+	repo := &UserRepositoryImpl{DB: db}
+
+	if err := repo.Delete(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	if !pool.committed {
+		t.Error("Delete did not commit its transaction")
+	}
+
+	tests := []struct {
+		name string
+		sql  string
+	}{
+		{"identities", `DELETE FROM "user_identities" WHERE user_id = $1`},
+		{"totp", `DELETE FROM "user_totps" WHERE user_id = $1`},
+		{"recovery codes", `DELETE FROM "recovery_codes" WHERE user_id = $1`},
+		{"reset tokens", `DELETE FROM "password_reset_tokens" WHERE user_id = $1`},
+		{"api keys", `DELETE FROM "api_keys" WHERE user_id = $1`},
+		{"refresh tokens", `DELETE FROM "refresh_tokens" WHERE session_id IN (SELECT "id" FROM "auth_sessions" WHERE user_id = $1)`},
+		{"sessions", `DELETE FROM "auth_sessions" WHERE user_id = $1`},
+		{"source code", `UPDATE "submissions" SET "source_code"=$1`},
+		{"personal data", `UPDATE "users" SET "avatar_url"=$1,"bio"=$2`},
+		{"soft delete", `UPDATE "users" SET "deleted_at"=$1 WHERE "users"."id" = $2`},
+	}
+
+	all := strings.Join(pool.statements, "\n")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(all, tt.sql) {
+				t.Errorf("no statement contains %s; got:\n%s", tt.sql, all)
+			}
+		})
+	}
+}
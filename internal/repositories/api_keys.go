@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *APIKeyRepositoryImpl) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	if err := s.DB.WithContext(ctx).Create(key).Error; err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyRepositoryImpl) ListByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := s.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (s *APIKeyRepositoryImpl) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := s.DB.WithContext(ctx).Model(&models.APIKey{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (s *APIKeyRepositoryImpl) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := s.DB.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &key, err
+}
+
+// Delete removes one of the user's keys. Keys of other users are reported as
+// ErrNotFound.
+func (s *APIKeyRepositoryImpl) Delete(ctx context.Context, userID uint, id uint) error {
+	result := s.DB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.APIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *APIKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id uint, t time.Time) error {
+	return s.DB.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", t).Error
+}
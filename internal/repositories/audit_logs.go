@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter narrows down AuditLogRepository.List. Zero values are
+// ignored.
+type AuditLogFilter struct {
+	ActorID uint
+	Action  string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+	Offset  int
+}
+
+type AuditLogRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *AuditLogRepositoryImpl) CreateBatch(ctx context.Context, logs []models.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return s.DB.WithContext(ctx).Create(&logs).Error
+}
+
+// List returns a page of audit logs, newest first, together with the total
+// number of matching logs.
+func (s *AuditLogRepositoryImpl) List(ctx context.Context, filter AuditLogFilter) ([]models.AuditLog, int64, error) {
+	query := s.DB.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AuditLog
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CacheKeyPatterns lists the caches operators can flush and their key
+// patterns.
+var CacheKeyPatterns = map[string][]string{
+	"users":      {"user-*"},
+	"sessions":   {"session-*"},
+	"languages":  {languagesCacheKey},
+	"games":      {"game-*"},
+	"lockouts":   {"login-fail-*", "login-lock-*"},
+	"ratelimits": {"ratelimit-*", "quota-*"},
+}
+
+// DerivedCaches only hold copies of data stored elsewhere and can always be
+// flushed safely. Lockouts and rate limits are state and must be named
+// explicitly.
+var DerivedCaches = []string{"users", "sessions", "languages", "games"}
+
+// CacheNames returns the names accepted by Flush.
+func CacheNames() []string {
+	names := make([]string, 0, len(CacheKeyPatterns))
+	for name := range CacheKeyPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Flush deletes every key of the named cache and returns how many were
+// deleted. Keys are found with SCAN so Redis is not blocked.
+func Flush(ctx context.Context, rdb *redis.Client, name string) (int64, error) {
+	patterns, ok := CacheKeyPatterns[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown cache %q", name)
+	}
+
+	var deleted int64
+	for _, pattern := range patterns {
+		iter := rdb.Scan(ctx, 0, pattern, 500).Iterator()
+		batch := make([]string, 0, 500)
+		for iter.Next(ctx) {
+			batch = append(batch, iter.Val())
+			if len(batch) == cap(batch) {
+				n, err := rdb.Del(ctx, batch...).Result()
+				if err != nil {
+					return deleted, err
+				}
+				deleted += n
+				batch = batch[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, err
+		}
+		if len(batch) > 0 {
+			n, err := rdb.Del(ctx, batch...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+		}
+	}
+	return deleted, nil
+}
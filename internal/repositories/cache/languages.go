@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/go-redis/redis/v8"
+)
+
+const languagesCacheKey = "judge0-languages"
+
+type LanguagesRedisImpl struct {
+	rdb *redis.Client
+}
+
+func (s *LanguagesRedisImpl) Get(ctx context.Context) (*models.LanguageCatalog, error) {
+	data, err := s.rdb.Get(ctx, languagesCacheKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var catalog models.LanguageCatalog
+	if err := json.Unmarshal([]byte(data), &catalog); err != nil {
+		return nil, err
+	}
+
+	return &catalog, nil
+}
+
+func (s *LanguagesRedisImpl) Set(ctx context.Context, catalog *models.LanguageCatalog, ttl time.Duration) error {
+	json, err := json.Marshal(catalog)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.SetEX(ctx, languagesCacheKey, json, ttl).Err()
+}
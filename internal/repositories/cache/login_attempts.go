@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// LoginAttemptRedisImpl counts failed sign-ins and holds temporary lockouts.
+// Counters start their window on the first failure, so a burst of failures
+// cannot be spread out to stay under the limit.
+type LoginAttemptRedisImpl struct {
+	rdb *redis.Client
+}
+
+var recordFailureScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+func (s *LoginAttemptRedisImpl) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	cacheKey := fmt.Sprintf("login-fail-%s", key)
+
+	return recordFailureScript.Run(ctx, s.rdb, []string{cacheKey}, window.Milliseconds()).Int64()
+}
+
+func (s *LoginAttemptRedisImpl) Reset(ctx context.Context, key string) error {
+	cacheKey := fmt.Sprintf("login-fail-%s", key)
+
+	return s.rdb.Del(ctx, cacheKey).Err()
+}
+
+func (s *LoginAttemptRedisImpl) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	cacheKey := fmt.Sprintf("login-lock-%s", key)
+
+	return s.rdb.SetEX(ctx, cacheKey, "1", ttl).Err()
+}
+
+// LockedFor returns how long the key stays locked, or zero if it is not.
+func (s *LoginAttemptRedisImpl) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	cacheKey := fmt.Sprintf("login-lock-%s", key)
+
+	ttl, err := s.rdb.PTTL(ctx, cacheKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	// 키가 없으면 음수 반환
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
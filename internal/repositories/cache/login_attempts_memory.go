@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LoginAttemptMemoryImpl is the in-process fallback used when Redis is
+// disabled.
+type LoginAttemptMemoryImpl struct {
+	mu       sync.Mutex
+	failures map[string]*memoryCounter
+	locks    map[string]time.Time
+}
+
+func NewLoginAttemptMemoryImpl() *LoginAttemptMemoryImpl {
+	return &LoginAttemptMemoryImpl{
+		failures: make(map[string]*memoryCounter),
+		locks:    make(map[string]time.Time),
+	}
+}
+
+func (s *LoginAttemptMemoryImpl) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.evictExpired(now)
+
+	counter, ok := s.failures[key]
+	if !ok {
+		counter = &memoryCounter{expireAt: now.Add(window)}
+		s.failures[key] = counter
+	}
+	counter.value++
+
+	return counter.value, nil
+}
+
+func (s *LoginAttemptMemoryImpl) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	return nil
+}
+
+func (s *LoginAttemptMemoryImpl) Lock(ctx context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks[key] = time.Now().Add(ttl)
+	return nil
+}
+
+func (s *LoginAttemptMemoryImpl) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.locks[key]
+	if !ok {
+		return 0, nil
+	}
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(s.locks, key)
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+func (s *LoginAttemptMemoryImpl) evictExpired(now time.Time) {
+	for key, counter := range s.failures {
+		if now.After(counter.expireAt) {
+			delete(s.failures, key)
+		}
+	}
+	for key, until := range s.locks {
+		if now.After(until) {
+			delete(s.locks, key)
+		}
+	}
+}
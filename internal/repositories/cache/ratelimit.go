@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RateLimitResult is the state of a token bucket after taking a token from it.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token is available, zero when allowed
+}
+
+// tokenBucketScript refills the bucket for the elapsed time and takes a single
+// token. Tokens are returned as a string because Lua numbers are truncated to
+// integers in Redis replies.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+type RateLimitRedisImpl struct {
+	rdb *redis.Client
+}
+
+func (s *RateLimitRedisImpl) Take(ctx context.Context, key string, capacity int, per time.Duration) (*RateLimitResult, error) {
+	cacheKey := fmt.Sprintf("ratelimit-%s", key)
+	rate := tokensPerMilli(capacity, per)
+
+	res, err := tokenBucketScript.Run(ctx, s.rdb, []string{cacheKey}, capacity, rate, time.Now().UnixMilli()).Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != 2 {
+		return nil, fmt.Errorf("unexpected rate limit script reply: %v", res)
+	}
+
+	allowed, _ := res[0].(int64)
+	tokensStr, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token count %q: %w", tokensStr, err)
+	}
+
+	return newRateLimitResult(allowed == 1, capacity, tokens, rate), nil
+}
+
+func (s *RateLimitRedisImpl) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	cacheKey := fmt.Sprintf("quota-%s", key)
+
+	pipe := s.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, cacheKey)
+	pipe.ExpireAt(ctx, cacheKey, expireAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func tokensPerMilli(capacity int, per time.Duration) float64 {
+	return float64(capacity) / float64(per.Milliseconds())
+}
+
+func newRateLimitResult(allowed bool, capacity int, tokens, rate float64) *RateLimitResult {
+	result := &RateLimitResult{
+		Allowed:    allowed,
+		Limit:      capacity,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration((float64(capacity)-tokens)/rate) * time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1-tokens)/rate) * time.Millisecond
+	}
+	return result
+}
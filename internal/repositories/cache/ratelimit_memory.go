@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitMemoryImpl is the in-process fallback used when Redis is disabled.
+// Limits are per instance, so it is only suitable for a single API server.
+type RateLimitMemoryImpl struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	counters  map[string]*memoryCounter
+	lastEvict time.Time
+	now       func() time.Time
+}
+
+type memoryBucket struct {
+	tokens float64
+	ts     time.Time
+	fullAt time.Time
+}
+
+type memoryCounter struct {
+	value    int64
+	expireAt time.Time
+}
+
+func NewRateLimitMemoryImpl() *RateLimitMemoryImpl {
+	return &RateLimitMemoryImpl{
+		buckets:  make(map[string]*memoryBucket),
+		counters: make(map[string]*memoryCounter),
+		now:      time.Now,
+	}
+}
+
+func (s *RateLimitMemoryImpl) Take(ctx context.Context, key string, capacity int, per time.Duration) (*RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := tokensPerMilli(capacity, per)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(capacity), ts: now}
+		s.buckets[key] = bucket
+	}
+
+	elapsed := float64(now.Sub(bucket.ts).Milliseconds())
+	bucket.tokens = math.Min(float64(capacity), bucket.tokens+math.Max(0, elapsed)*rate)
+	bucket.ts = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	result := newRateLimitResult(allowed, capacity, bucket.tokens, rate)
+	bucket.fullAt = now.Add(result.ResetAfter)
+
+	s.evictExpired(now)
+
+	return result, nil
+}
+
+func (s *RateLimitMemoryImpl) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	counter, ok := s.counters[key]
+	if !ok || now.After(counter.expireAt) {
+		counter = &memoryCounter{}
+		s.counters[key] = counter
+	}
+	counter.value++
+	counter.expireAt = expireAt
+
+	return counter.value, nil
+}
+
+// evictExpired drops buckets that have been idle long enough to be full again
+// and counters past their expiry so the maps do not grow without bound.
+func (s *RateLimitMemoryImpl) evictExpired(now time.Time) {
+	if now.Sub(s.lastEvict) < time.Minute {
+		return
+	}
+	s.lastEvict = now
+
+	for key, bucket := range s.buckets {
+		if now.After(bucket.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, counter := range s.counters {
+		if now.After(counter.expireAt) {
+			delete(s.counters, key)
+		}
+	}
+}
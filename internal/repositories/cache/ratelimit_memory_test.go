@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock lets the tests move time forward without sleeping.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestRateLimiter() (*RateLimitMemoryImpl, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewRateLimitMemoryImpl()
+	s.now = clock.now
+	return s, clock
+}
+
+func take(t *testing.T, s *RateLimitMemoryImpl, key string, capacity int, per time.Duration) *RateLimitResult {
+	t.Helper()
+	result, err := s.Take(context.Background(), key, capacity, per)
+	if err != nil {
+		t.Fatalf("Take(%q) returned error: %v", key, err)
+	}
+	return result
+}
+
+func TestRateLimitMemoryBurst(t *testing.T) {
+	s, _ := newTestRateLimiter()
+
+	for i := 0; i < 5; i++ {
+		result := take(t, s, "burst", 5, time.Minute)
+		if !result.Allowed {
+			t.Fatalf("request %d was denied within the burst", i+1)
+		}
+		if want := 4 - i; result.Remaining != want {
+			t.Errorf("request %d: Remaining = %d, want %d", i+1, result.Remaining, want)
+		}
+		if result.Limit != 5 {
+			t.Errorf("request %d: Limit = %d, want 5", i+1, result.Limit)
+		}
+	}
+
+	result := take(t, s, "burst", 5, time.Minute)
+	if result.Allowed {
+		t.Fatal("request after the burst was allowed")
+	}
+	// 5 tokens/min 이면 토큰 하나가 12초마다 채워짐
+	if result.RetryAfter != 12*time.Second {
+		t.Errorf("RetryAfter = %v, want 12s", result.RetryAfter)
+	}
+	if result.ResetAfter != time.Minute {
+		t.Errorf("ResetAfter = %v, want 1m", result.ResetAfter)
+	}
+}
+
+func TestRateLimitMemoryRefill(t *testing.T) {
+	tests := []struct {
+		name      string
+		wait      time.Duration
+		allowed   bool
+		remaining int
+	}{
+		{"not yet refilled", 11 * time.Second, false, 0},
+		{"one token", 12 * time.Second, true, 0},
+		{"two tokens", 24 * time.Second, true, 1},
+		{"capped at capacity", time.Hour, true, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, clock := newTestRateLimiter()
+			for i := 0; i < 5; i++ {
+				take(t, s, "refill", 5, time.Minute)
+			}
+
+			clock.advance(tt.wait)
+			result := take(t, s, "refill", 5, time.Minute)
+			if result.Allowed != tt.allowed {
+				t.Errorf("Allowed = %v, want %v", result.Allowed, tt.allowed)
+			}
+			if result.Remaining != tt.remaining {
+				t.Errorf("Remaining = %d, want %d", result.Remaining, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestRateLimitMemoryKeyIsolation(t *testing.T) {
+	s, _ := newTestRateLimiter()
+
+	take(t, s, "code.submit:user:1", 1, time.Minute)
+	if take(t, s, "code.submit:user:1", 1, time.Minute).Allowed {
+		t.Fatal("second request for user 1 was allowed")
+	}
+
+	for _, key := range []string{"code.submit:user:2", "code.submit:ip:1", "other:user:1"} {
+		if !take(t, s, key, 1, time.Minute).Allowed {
+			t.Errorf("%q was limited by another key's bucket", key)
+		}
+	}
+}
+
+func TestRateLimitMemoryEvictsFullBuckets(t *testing.T) {
+	s, clock := newTestRateLimiter()
+
+	take(t, s, "idle", 2, time.Minute)
+	clock.advance(2 * time.Minute)
+	take(t, s, "active", 2, time.Minute)
+
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("idle bucket was not evicted after it refilled")
+	}
+	if _, ok := s.buckets["active"]; !ok {
+		t.Error("active bucket was evicted")
+	}
+}
+
+func TestRateLimitMemoryIncr(t *testing.T) {
+	s, clock := newTestRateLimiter()
+	ctx := context.Background()
+	expireAt := clock.now().Add(time.Hour)
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := s.Incr(ctx, "quota", expireAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Incr = %d, want %d", got, want)
+		}
+	}
+
+	clock.advance(time.Hour + time.Second)
+	got, err := s.Incr(ctx, "quota", clock.now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 1 {
+		t.Errorf("Incr after expiry = %d, want 1", got)
+	}
+}
@@ -1,11 +1,16 @@
 package cache
 
-import "github.com/go-redis/redis/v8"
+import (
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
+	"github.com/go-redis/redis/v8"
+)
 
 func NewRedisClient(addr, pw string, db int) *redis.Client {
-	return redis.NewClient(&redis.Options{
+	rdb := redis.NewClient(&redis.Options{
 		Addr:     addr,
 		Password: pw,
 		DB:       db,
 	})
+	rdb.AddHook(tracing.RedisHook{})
+	return rdb
 }
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	sessionActive  = "active"
+	sessionRevoked = "revoked"
+)
+
+// SessionRedisImpl caches whether a session is still active so that
+// AuthMiddleware does not query Postgres on every request.
+type SessionRedisImpl struct {
+	rdb *redis.Client
+}
+
+// Get returns the cached state of the session; found is false on a miss.
+func (s *SessionRedisImpl) Get(ctx context.Context, sessionID string) (active bool, found bool, err error) {
+	cacheKey := fmt.Sprintf("session-%s", sessionID)
+
+	data, err := s.rdb.Get(ctx, cacheKey).Result()
+	if err == redis.Nil {
+		return false, false, nil
+	} else if err != nil {
+		return false, false, err
+	}
+
+	return data == sessionActive, true, nil
+}
+
+func (s *SessionRedisImpl) Set(ctx context.Context, sessionID string, active bool, ttl time.Duration) error {
+	cacheKey := fmt.Sprintf("session-%s", sessionID)
+
+	state := sessionRevoked
+	if active {
+		state = sessionActive
+	}
+
+	return s.rdb.SetEX(ctx, cacheKey, state, ttl).Err()
+}
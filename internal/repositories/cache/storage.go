@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/mapper"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
@@ -21,14 +22,62 @@ type GameRedisStoreInterface interface {
 	Delete(context.Context, int) error
 }
 
+type LanguagesRedisStoreInterface interface {
+	Get(context.Context) (*models.LanguageCatalog, error)
+	Set(context.Context, *models.LanguageCatalog, time.Duration) error
+}
+
+type RateLimitStoreInterface interface {
+	Take(ctx context.Context, key string, capacity int, per time.Duration) (*RateLimitResult, error)
+	Incr(ctx context.Context, key string, expireAt time.Time) (int64, error)
+}
+
+type SessionRedisStoreInterface interface {
+	Get(ctx context.Context, sessionID string) (active bool, found bool, err error)
+	Set(ctx context.Context, sessionID string, active bool, ttl time.Duration) error
+}
+
+type TicketStoreInterface interface {
+	Set(ctx context.Context, kind, id, value string, ttl time.Duration) error
+	Consume(ctx context.Context, kind, id string) (string, bool, error)
+}
+
+type LoginAttemptStoreInterface interface {
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+	Reset(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, ttl time.Duration) error
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
 type RedisStorage struct {
-	Users UsersRedisStoreInterface
-	Games GameRedisStoreInterface
+	Users      UsersRedisStoreInterface
+	Games      GameRedisStoreInterface
+	Languages  LanguagesRedisStoreInterface
+	RateLimits RateLimitStoreInterface
+	Sessions   SessionRedisStoreInterface
+	Tickets    TicketStoreInterface
+	// LoginAttempts tracks failed sign-ins for lockouts.
+	LoginAttempts LoginAttemptStoreInterface
 }
 
+// NewRedisStorage wires the Redis backed stores. When rbd is nil (Redis
+// disabled) stores that have an in-memory fallback use it instead.
 func NewRedisStorage(rbd *redis.Client) RedisStorage {
-	return RedisStorage{
-		Users: &UserRedisImpl{rdb: rbd},
-		Games: &GameRedisImpl{rdb: rbd},
+	storage := RedisStorage{
+		Users:         &UserRedisImpl{rdb: rbd},
+		Games:         &GameRedisImpl{rdb: rbd},
+		Languages:     &LanguagesRedisImpl{rdb: rbd},
+		RateLimits:    &RateLimitRedisImpl{rdb: rbd},
+		Sessions:      &SessionRedisImpl{rdb: rbd},
+		Tickets:       &TicketRedisImpl{rdb: rbd},
+		LoginAttempts: &LoginAttemptRedisImpl{rdb: rbd},
 	}
+
+	if rbd == nil {
+		storage.RateLimits = NewRateLimitMemoryImpl()
+		storage.Tickets = NewTicketMemoryImpl()
+		storage.LoginAttempts = NewLoginAttemptMemoryImpl()
+	}
+
+	return storage
 }
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Ticket kinds
+const (
+	TicketWebSocket  = "ws"
+	TicketOAuthState = "oauth"
+	TicketTwoFactor  = "2fa"
+)
+
+// TicketRedisImpl stores short-lived single-use tickets. Consume reads and
+// deletes a ticket atomically so it can never be redeemed twice.
+type TicketRedisImpl struct {
+	rdb *redis.Client
+}
+
+func (s *TicketRedisImpl) Set(ctx context.Context, kind, id, value string, ttl time.Duration) error {
+	cacheKey := fmt.Sprintf("ticket-%s-%s", kind, id)
+
+	return s.rdb.SetEX(ctx, cacheKey, value, ttl).Err()
+}
+
+func (s *TicketRedisImpl) Consume(ctx context.Context, kind, id string) (string, bool, error) {
+	cacheKey := fmt.Sprintf("ticket-%s-%s", kind, id)
+
+	value, err := s.rdb.GetDel(ctx, cacheKey).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TicketMemoryImpl is the in-process fallback used when Redis is disabled.
+type TicketMemoryImpl struct {
+	mu      sync.Mutex
+	tickets map[string]memoryTicket
+}
+
+type memoryTicket struct {
+	value    string
+	expireAt time.Time
+}
+
+func NewTicketMemoryImpl() *TicketMemoryImpl {
+	return &TicketMemoryImpl{tickets: make(map[string]memoryTicket)}
+}
+
+func (s *TicketMemoryImpl) Set(ctx context.Context, kind, id, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, ticket := range s.tickets {
+		if now.After(ticket.expireAt) {
+			delete(s.tickets, key)
+		}
+	}
+
+	s.tickets[kind+"-"+id] = memoryTicket{value: value, expireAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *TicketMemoryImpl) Consume(ctx context.Context, kind, id string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := kind + "-" + id
+	ticket, ok := s.tickets[key]
+	if !ok {
+		return "", false, nil
+	}
+	delete(s.tickets, key)
+
+	if time.Now().After(ticket.expireAt) {
+		return "", false, nil
+	}
+	return ticket.value, true, nil
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+func (s *UserRepositoryImpl) UsernameExists(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := s.DB.WithContext(ctx).Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+func (s *UserRepositoryImpl) GetIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := s.DB.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &identity, err
+}
+
+func (s *UserRepositoryImpl) CreateIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	return s.DB.WithContext(ctx).Create(identity).Error
+}
+
+// CreateWithIdentity creates a user signing in with an external provider for
+// the first time together with the identity it signed in with.
+func (s *UserRepositoryImpl) CreateWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) (*models.User, error) {
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
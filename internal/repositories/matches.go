@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MatchRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// RecordMatch stores a finished match. For a rated match, rate receives the
+// participants' current ratings and returns the new ones; the ratings are
+// read with row locks so concurrent matches cannot lose an update.
+func (s *MatchRepositoryImpl) RecordMatch(ctx context.Context, match *models.Match, rate func(map[uint]int) map[uint]int) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		userIDs := make([]uint, len(match.Participants))
+		for i, p := range match.Participants {
+			userIDs[i] = p.UserID
+		}
+
+		var users []models.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "rating").
+			Where("id IN ?", userIDs).
+			Order("id").
+			Find(&users).Error
+		if err != nil {
+			return err
+		}
+
+		ratings := make(map[uint]int, len(users))
+		for _, u := range users {
+			ratings[u.ID] = u.Rating
+		}
+
+		updated := ratings
+		if match.Rated {
+			updated = rate(ratings)
+		}
+
+		for i := range match.Participants {
+			p := &match.Participants[i]
+			p.RatingBefore = ratings[p.UserID]
+			p.RatingAfter = updated[p.UserID]
+			if p.RatingAfter == p.RatingBefore {
+				continue
+			}
+			err := tx.Model(&models.User{}).
+				Where("id = ?", p.UserID).
+				Update("rating", p.RatingAfter).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(match).Error
+	})
+}
+
+// UserStats returns how many matches the user played and won.
+func (s *MatchRepositoryImpl) UserStats(ctx context.Context, userID uint) (played int64, wins int64, err error) {
+	var row struct {
+		Played int64
+		Wins   int64
+	}
+	err = s.DB.WithContext(ctx).
+		Model(&models.MatchParticipant{}).
+		Select("COUNT(*) AS played, COUNT(*) FILTER (WHERE won) AS wins").
+		Where("user_id = ?", userID).
+		Scan(&row).Error
+
+	return row.Played, row.Wins, err
+}
+
+// ListByUser returns the matches the user took part in, oldest first, with
+// all participants.
+func (s *MatchRepositoryImpl) ListByUser(ctx context.Context, userID uint) ([]models.Match, error) {
+	var matches []models.Match
+	err := s.DB.WithContext(ctx).
+		Preload("Participants").
+		Where("id IN (?)", s.DB.Model(&models.MatchParticipant{}).Select("match_id").Where("user_id = ?", userID)).
+		Order("id").
+		Find(&matches).Error
+	return matches, err
+}
+
+// RecomputeRatings replays every rated match in the order the matches
+// finished, starting everyone at models.DefaultRating, and rewrites the
+// ratings stored on participants and users. rate receives the ratings before
+// a match and returns the ratings after it. It returns the number of matches
+// replayed.
+func (s *MatchRepositoryImpl) RecomputeRatings(ctx context.Context, rate func(ratings map[uint]int, match *models.Match) map[uint]int) (int, error) {
+	replayed := 0
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		// 재계산 중에는 새 경기 기록과 레이팅 변경을 막음 (RecordMatch와 같은 순서로 잠금)
+		if err := tx.Exec("LOCK TABLE users, matches IN EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		ratings := make(map[uint]int)
+		var batch []models.Match
+		result := tx.Preload("Participants").
+			Where("rated = ?", true).
+			Order("finished_at, id").
+			FindInBatches(&batch, 500, func(_ *gorm.DB, _ int) error {
+				for i := range batch {
+					match := &batch[i]
+					before := make(map[uint]int, len(match.Participants))
+					for _, p := range match.Participants {
+						r, ok := ratings[p.UserID]
+						if !ok {
+							r = models.DefaultRating
+						}
+						before[p.UserID] = r
+					}
+
+					after := rate(before, match)
+					for _, p := range match.Participants {
+						ratings[p.UserID] = after[p.UserID]
+						if p.RatingBefore == before[p.UserID] && p.RatingAfter == after[p.UserID] {
+							continue
+						}
+						err := tx.Model(&models.MatchParticipant{}).
+							Where("id = ?", p.ID).
+							Updates(map[string]interface{}{
+								"rating_before": before[p.UserID],
+								"rating_after":  after[p.UserID],
+							}).Error
+						if err != nil {
+							return err
+						}
+					}
+					replayed++
+				}
+				return nil
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// 익명화된 계정도 포함해 초기화 후 다시 반영
+		err := tx.Unscoped().Model(&models.User{}).
+			Where("rating <> ?", models.DefaultRating).
+			Update("rating", models.DefaultRating).Error
+		if err != nil {
+			return err
+		}
+		for userID, rating := range ratings {
+			if rating == models.DefaultRating {
+				continue
+			}
+			err := tx.Unscoped().Model(&models.User{}).
+				Where("id = ?", userID).
+				Update("rating", rating).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return replayed, nil
+}
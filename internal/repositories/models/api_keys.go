@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// API 키 범위. 키는 소유자의 권한 중 여기 나열된 것만 사용할 수 있다.
+const (
+	ScopeCodeSubmit      = "code:submit"
+	ScopeGamesRead       = "games:read"
+	ScopeProblemsRead    = "problems:read"
+	ScopeSubmissionsRead = "submissions:read"
+)
+
+// APIKeyScopes lists every scope a key can be granted.
+var APIKeyScopes = []string{
+	ScopeCodeSubmit,
+	ScopeGamesRead,
+	ScopeProblemsRead,
+	ScopeSubmissionsRead,
+}
+
+// APIKey is a personal access key for scripts and CI. Only the hash of the
+// key is stored; Prefix is kept so users can tell their keys apart.
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	Name       string     `gorm:"size:64;not null" json:"name"`
+	Prefix     string     `gorm:"size:16;not null" json:"prefix"`
+	KeyHash    string     `gorm:"size:64;uniqueIndex;not null" json:"-"` // sha256, 원문은 저장하지 않음
+	Scopes     string     `gorm:"size:255;not null" json:"-"`            // 공백으로 구분
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // nil이면 만료 없음
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ScopeList returns the scopes granted to the key.
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// HasScope reports whether the key was granted the named scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the key can no longer be used at time t.
+func (k *APIKey) IsExpired(t time.Time) bool {
+	return k.ExpiresAt != nil && !t.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AuditLog records a security-relevant action. ActorID is nil when the actor
+// is unknown, e.g. a failed sign-in.
+type AuditLog struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Action     string    `gorm:"size:64;index;not null" json:"action"`
+	ActorID    *uint     `gorm:"index" json:"actor_id,omitempty"`
+	TargetType string    `gorm:"size:32" json:"target_type,omitempty"`
+	TargetID   string    `gorm:"size:64" json:"target_id,omitempty"`
+	Success    bool      `gorm:"not null" json:"success"`
+	IP         string    `gorm:"size:45" json:"ip"`
+	UserAgent  string    `gorm:"size:512" json:"user_agent"`
+	Metadata   string    `gorm:"type:jsonb;not null;default:'{}'" json:"-"` // JSON 객체
+	CreatedAt  time.Time `gorm:"index;not null" json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UserIdentity links an account at an external OAuth provider to a user. A
+// user can have several identities, one per provider account.
+type UserIdentity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"index;not null" json:"user_id"`
+	Provider  string    `gorm:"size:32;not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject   string    `gorm:"not null;uniqueIndex:idx_identity_provider_subject" json:"-"` // 제공자의 사용자 ID
+	Email     string    `json:"email"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Language struct {
+	ID         int    `json:"id"` // Judge0 language id
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	Judge0Name string `json:"judge0_name"`
+	Enabled    bool   `json:"enabled"`
+	// Default marks the newest version of a language, used where a slug has
+	// to be mapped back to one id (templates, problem packages).
+	Default    bool   `json:"default"`
+	MonacoMode string `json:"monaco_mode,omitempty"`
+	Extension  string `json:"extension,omitempty"`
+	Template   string `json:"template,omitempty"`
+}
+
+type LanguageCatalog struct {
+	Languages []Language             `json:"languages"`
+	About     map[string]interface{} `json:"about,omitempty"`
+	FetchedAt time.Time              `json:"fetched_at"`
+}
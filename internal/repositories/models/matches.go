@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Match is a finished multiplayer race.
+type Match struct {
+	ID           uint               `gorm:"primaryKey" json:"id"`
+	RoomID       string             `gorm:"size:36;not null" json:"room_id"`
+	ProblemID    uint               `gorm:"index;not null" json:"problem_id"`
+	WinnerID     *uint              `gorm:"index" json:"winner_id,omitempty"`
+	Rated        bool               `gorm:"not null;default:false" json:"rated"`
+	StartedAt    time.Time          `gorm:"not null" json:"started_at"`
+	FinishedAt   time.Time          `gorm:"not null;index" json:"finished_at"`
+	Participants []MatchParticipant `json:"participants,omitempty"`
+}
+
+type MatchParticipant struct {
+	ID           uint `gorm:"primaryKey" json:"-"`
+	MatchID      uint `gorm:"index;not null" json:"-"`
+	UserID       uint `gorm:"index;not null" json:"user_id"`
+	LanguageID   int  `json:"language_id,omitempty"` // 마지막으로 제출한 언어, 제출하지 않았으면 0
+	Won          bool `gorm:"not null;default:false" json:"won"`
+	RatingBefore int  `json:"rating_before"`
+	RatingAfter  int  `json:"rating_after"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use token mailed by the forgot-password
+// flow. Only its hash is stored.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"index;not null"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null"` // sha256, 원문은 저장하지 않음
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // 사용된 시각
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// PracticeSession is a solo attempt at a problem. The solve time is measured
+// from StartedAt to the first accepted submission.
+type PracticeSession struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	ProblemID uint       `gorm:"index;not null" json:"problem_id"`
+	StartedAt time.Time  `gorm:"not null" json:"started_at"`
+	SolvedAt  *time.Time `json:"solved_at,omitempty"`
+}
+
+type PersonalBest struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"uniqueIndex:idx_personal_bests_user_problem;not null" json:"user_id"`
+	ProblemID    uint      `gorm:"uniqueIndex:idx_personal_bests_user_problem;not null" json:"problem_id"`
+	SubmissionID uint      `gorm:"not null" json:"submission_id"`
+	LanguageID   int       `gorm:"not null" json:"language_id"`
+	BestTimeMs   int64     `gorm:"not null" json:"best_time_ms"`
+	AchievedAt   time.Time `gorm:"not null" json:"achieved_at"`
+}
+
+// ProblemStats aggregates submissions and personal bests of a problem.
+type ProblemStats struct {
+	ProblemID           uint    `json:"problem_id"`
+	TotalSubmissions    int64   `json:"total_submissions"`
+	AcceptedSubmissions int64   `json:"accepted_submissions"`
+	AcceptanceRate      float64 `json:"acceptance_rate"`
+	Solvers             int64   `json:"solvers"`
+	MedianSolveTimeMs   int64   `json:"median_solve_time_ms"`
+}
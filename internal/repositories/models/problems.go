@@ -0,0 +1,148 @@
+package models
+
+import "time"
+
+// CompareMode decides how a program's output is compared to the expected one.
+type CompareMode string
+
+const (
+	CompareExact           CompareMode = "exact"
+	CompareWhitespace      CompareMode = "whitespace"
+	CompareCaseInsensitive CompareMode = "case_insensitive"
+	CompareFloat           CompareMode = "float"
+	CompareUnorderedLines  CompareMode = "unordered_lines"
+	CompareCustom          CompareMode = "custom"
+)
+
+type Problem struct {
+	ID             uint        `gorm:"primaryKey" json:"id"`
+	Slug           string      `gorm:"unique;not null" json:"slug"`
+	Title          string      `gorm:"not null" json:"title"`
+	Statement      string      `gorm:"type:text" json:"statement"`               // Markdown
+	Locale         string      `gorm:"size:8;not null;default:en" json:"locale"` // Title, Statement 의 언어
+	Difficulty     string      `json:"difficulty"`
+	TimeLimit      float64     `gorm:"default:2" json:"time_limit"`        // 초 단위 CPU 시간 제한
+	MemoryLimit    int         `gorm:"default:128000" json:"memory_limit"` // KB 단위 메모리 제한
+	CompareMode    CompareMode `gorm:"default:exact" json:"compare_mode"`
+	FloatTolerance float64     `gorm:"default:0.000001" json:"float_tolerance,omitempty"`
+	// 커스텀 체커 (CompareMode 가 custom 일 때만 사용)
+	CheckerLanguageID int    `json:"-"`
+	CheckerSource     string `gorm:"type:text" json:"-"`
+	// 정답 코드. 가져오기와 공개 전에 모든 테스트를 통과하는지 확인
+	ReferenceLanguageID int                `json:"-"`
+	ReferenceSource     string             `gorm:"type:text" json:"-"`
+	IsPublished         bool               `gorm:"default:false" json:"is_published"`
+	Version             int                `gorm:"not null;default:1" json:"version"` // 내용이 바뀔 때마다 증가
+	TestCases           []TestCase         `gorm:"foreignKey:ProblemID" json:"test_cases,omitempty"`
+	Templates           []ProblemTemplate  `gorm:"foreignKey:ProblemID" json:"templates,omitempty"`
+	Statements          []ProblemStatement `gorm:"foreignKey:ProblemID" json:"statements,omitempty"` // 번역
+	CreatedAt           time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type TestCase struct {
+	ID             uint   `gorm:"primaryKey" json:"id"`
+	ProblemID      uint   `gorm:"index;not null" json:"problem_id"`
+	Input          string `gorm:"type:text" json:"input"`
+	ExpectedOutput string `gorm:"type:text" json:"expected_output"`
+	IsSample       bool   `gorm:"default:false" json:"is_sample"`
+	Position       int    `gorm:"not null" json:"position"`
+}
+
+// ProblemTemplate is the starter code of a problem for one language,
+// overriding the language's default template.
+type ProblemTemplate struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	ProblemID    uint   `gorm:"uniqueIndex:idx_problem_templates_problem_language;not null" json:"problem_id"`
+	LanguageSlug string `gorm:"uniqueIndex:idx_problem_templates_problem_language;not null" json:"language_slug"`
+	Code         string `gorm:"type:text;not null" json:"code"`
+}
+
+// ProblemStatement is a translation of a problem's title and statement.
+type ProblemStatement struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	ProblemID uint   `gorm:"uniqueIndex:idx_problem_statements_problem_locale;not null" json:"problem_id"`
+	Locale    string `gorm:"uniqueIndex:idx_problem_statements_problem_locale;size:8;not null" json:"locale"`
+	Title     string `gorm:"not null" json:"title"`
+	Statement string `gorm:"type:text;not null" json:"statement"`
+}
+
+// Locales returns the locales the problem is written in, main one first.
+func (p *Problem) Locales() []string {
+	locales := []string{p.Locale}
+	for _, s := range p.Statements {
+		locales = append(locales, s.Locale)
+	}
+	return locales
+}
+
+// Localized returns the title and statement in the first preferred locale
+// the problem has, falling back to the main statement.
+func (p *Problem) Localized(preferred []string) (locale, title, statement string) {
+	for _, want := range preferred {
+		if want == p.Locale {
+			break
+		}
+		for _, s := range p.Statements {
+			if s.Locale == want {
+				return s.Locale, s.Title, s.Statement
+			}
+		}
+	}
+	return p.Locale, p.Title, p.Statement
+}
+
+// Samples returns the test cases that may be shown to players.
+func (p *Problem) Samples() []TestCase {
+	samples := make([]TestCase, 0)
+	for _, tc := range p.TestCases {
+		if tc.IsSample {
+			samples = append(samples, tc)
+		}
+	}
+	return samples
+}
+
+// ProblemVersion is the content of a problem as saved by an author. A new
+// version is stored every time the statement, limits, tests or templates
+// change; publishing does not create one.
+type ProblemVersion struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProblemID uint      `gorm:"uniqueIndex:idx_problem_versions_problem_version;not null" json:"problem_id"`
+	Version   int       `gorm:"uniqueIndex:idx_problem_versions_problem_version;not null" json:"version"`
+	AuthorID  *uint     `json:"author_id,omitempty"`          // 명령줄이나 시드로 저장되면 nil
+	Snapshot  string    `gorm:"type:jsonb;not null" json:"-"` // ProblemSnapshot
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ProblemSnapshot is the complete content of a problem, including the
+// checker and reference solution hidden from players. It is the format of
+// stored versions and of JSON imports and exports.
+type ProblemSnapshot struct {
+	Problem
+	CheckerLanguageID   int    `json:"checker_language_id,omitempty"`
+	CheckerSource       string `json:"checker_source,omitempty"`
+	ReferenceLanguageID int    `json:"reference_language_id,omitempty"`
+	ReferenceSource     string `json:"reference_source,omitempty"`
+}
+
+// NewProblemSnapshot copies the problem with its hidden fields.
+func NewProblemSnapshot(p *Problem) *ProblemSnapshot {
+	return &ProblemSnapshot{
+		Problem:             *p,
+		CheckerLanguageID:   p.CheckerLanguageID,
+		CheckerSource:       p.CheckerSource,
+		ReferenceLanguageID: p.ReferenceLanguageID,
+		ReferenceSource:     p.ReferenceSource,
+	}
+}
+
+// ToProblem returns the problem with its hidden fields restored.
+func (s *ProblemSnapshot) ToProblem() *Problem {
+	p := s.Problem
+	p.CheckerLanguageID = s.CheckerLanguageID
+	p.CheckerSource = s.CheckerSource
+	p.ReferenceLanguageID = s.ReferenceLanguageID
+	p.ReferenceSource = s.ReferenceSource
+	return &p
+}
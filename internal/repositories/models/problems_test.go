@@ -0,0 +1,52 @@
+package models
+
+import "testing"
+
+func TestProblemLocalized(t *testing.T) {
+	problem := &Problem{
+		Locale:    "en",
+		Title:     "Two Sum",
+		Statement: "Add two numbers.",
+		Statements: []ProblemStatement{
+			{Locale: "ko", Title: "두 수의 합", Statement: "두 수를 더하세요."},
+			{Locale: "ja", Title: "二つの和", Statement: "二つの数を足してください。"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		preferred  []string
+		wantLocale string
+		wantTitle  string
+	}{
+		{"no preference", nil, "en", "Two Sum"},
+		{"main locale", []string{"en"}, "en", "Two Sum"},
+		{"translation", []string{"ko"}, "ko", "두 수의 합"},
+		{"first available wins", []string{"ja", "ko"}, "ja", "二つの和"},
+		{"unknown skipped", []string{"fr", "ko"}, "ko", "두 수의 합"},
+		// 원문이 번역보다 앞에 있으면 원문을 사용
+		{"main before translation", []string{"en", "ko"}, "en", "Two Sum"},
+		{"main between translations", []string{"fr", "en", "ja"}, "en", "Two Sum"},
+		{"nothing matches", []string{"fr", "de"}, "en", "Two Sum"},
+		{"locales are exact", []string{"KO", "ko-KR"}, "en", "Two Sum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, title, statement := problem.Localized(tt.preferred)
+			if locale != tt.wantLocale || title != tt.wantTitle {
+				t.Fatalf("Localized(%q) = (%q, %q), want (%q, %q)", tt.preferred, locale, title, tt.wantLocale, tt.wantTitle)
+			}
+			if statement == "" {
+				t.Errorf("Localized(%q) returned an empty statement", tt.preferred)
+			}
+		})
+	}
+}
+
+func TestProblemLocalizedWithoutTranslations(t *testing.T) {
+	problem := &Problem{Locale: "ko", Title: "제목", Statement: "본문"}
+	if locale, title, statement := problem.Localized([]string{"en", "ja"}); locale != "ko" || title != "제목" || statement != "본문" {
+		t.Errorf("Localized() = (%q, %q, %q), want the main statement", locale, title, statement)
+	}
+}
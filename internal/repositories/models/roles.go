@@ -1,6 +1,58 @@
 package models
 
+// 기본 역할 이름
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// 권한 이름. 라우트와 서비스는 역할 이름 대신 권한으로 검사한다.
+const (
+	PermissionGameStatusRead    = "game:status:read"
+	PermissionRoomModerate      = "room:moderate"
+	PermissionSubmissionReadAll = "submission:read:all"
+	PermissionProblemManage     = "problem:manage"
+	PermissionUserManage        = "user:manage"
+	PermissionAuditRead         = "audit:read"
+)
+
 type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `gorm:"unique;not null" json:"name"`
+	Permissions []Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
+}
+
+type Permission struct {
 	ID   uint   `gorm:"primaryKey" json:"id"`
 	Name string `gorm:"unique;not null" json:"name"`
 }
+
+// DefaultRolePermissions is seeded at startup. Existing grants are kept, so
+// permissions added by hand survive a restart.
+var DefaultRolePermissions = map[string][]string{
+	RoleUser: {},
+	RoleModerator: {
+		PermissionGameStatusRead,
+		PermissionRoomModerate,
+		PermissionSubmissionReadAll,
+	},
+	RoleAdmin: {
+		PermissionGameStatusRead,
+		PermissionRoomModerate,
+		PermissionSubmissionReadAll,
+		PermissionProblemManage,
+		PermissionUserManage,
+		PermissionAuditRead,
+	},
+}
+
+// HasPermission reports whether the role grants the named permission.
+func (r *Role) HasPermission(name string) bool {
+	for _, p := range r.Permissions {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// AuthSession is a sign-in on one device. All refresh tokens rotated from the
+// same sign-in belong to it, so revoking the session revokes the whole token
+// family and every access token carrying its id.
+type AuthSession struct {
+	ID        string     `gorm:"primaryKey;size:36" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	UserAgent string     `json:"user_agent"`
+	IP        string     `json:"ip"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsActive reports whether the session can still be used at time t.
+func (s *AuthSession) IsActive(t time.Time) bool {
+	return s.RevokedAt == nil && t.Before(s.ExpiresAt)
+}
+
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey"`
+	SessionID string     `gorm:"size:36;index;not null"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null"` // sha256, 원문은 저장하지 않음
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // 교체(rotation)된 시각
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Submission struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"index;not null" json:"user_id"`
+	ProblemID  *uint     `gorm:"index" json:"problem_id,omitempty"` // 문제 없이 실행한 코드는 nil
+	LanguageID int       `gorm:"not null" json:"language_id"`
+	SourceHash string    `gorm:"size:64;index;not null" json:"source_hash"` // sha256
+	SourceCode string    `gorm:"type:text;not null" json:"source_code,omitempty"`
+	Verdict    string    `gorm:"index;not null" json:"verdict"`
+	Time       float64   `json:"time"`   // 초
+	Memory     int       `json:"memory"` // KB
+	CreatedAt  time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// UserTOTP is a user's authenticator app secret. 2FA is enabled once the
+// enrollment is confirmed with a first valid code.
+type UserTOTP struct {
+	UserID       uint       `gorm:"primaryKey"`
+	Secret       string     `gorm:"not null"`
+	ConfirmedAt  *time.Time // nil이면 등록 진행 중
+	LastUsedStep int64      `gorm:"not null;default:0"` // 같은 코드 재사용 방지
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+}
+
+// Enabled reports whether the enrollment was confirmed.
+func (t *UserTOTP) Enabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+type RecoveryCode struct {
+	ID       uint       `gorm:"primaryKey"`
+	UserID   uint       `gorm:"index;not null"`
+	CodeHash string     `gorm:"size:64;not null"` // sha256, 원문은 저장하지 않음
+	UsedAt   *time.Time // 사용된 시각
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -11,6 +13,22 @@ type User struct {
 	Email     string    `gorm:"unique;not null" json:"email"`            // 이메일
 	RoleID    uint      `gorm:"not null" json:"role_id"`                 // 역할 ID
 	Role      *Role     `gorm:"foreignKey:RoleID" json:"role,omitempty"` // 역할 정보 (null일 경우 생략)
-	IsActive  bool      `gorm:"default:true" json:"is_active"`           // 활성화 여부
+	IsActive  bool      `gorm:"not null;default:false" json:"is_active"` // 이메일 인증 여부
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`        // 생성 시간
+
+	// 프로필
+	DisplayName       string `gorm:"size:50" json:"display_name"`
+	Bio               string `gorm:"size:500" json:"bio"`
+	AvatarURL         string `gorm:"size:512" json:"avatar_url"`
+	PreferredLanguage string `gorm:"size:32" json:"preferred_language"` // 언어 slug
+	EditorTheme       string `gorm:"size:32" json:"editor_theme"`
+	Locale            string `gorm:"size:8" json:"locale"`                // 문제 설명 언어, 비어 있으면 Accept-Language
+	Rating            int    `gorm:"not null;default:1200" json:"rating"` // Elo
+
+	// 탈퇴 요청 후 유예 기간이 끝나는 시각, 그 전에 로그인하면 취소됨
+	DeletionScheduledAt *time.Time     `gorm:"index" json:"deletion_scheduled_at,omitempty"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"` // 익명화된 계정
 }
+
+// DefaultRating is the Elo rating every account starts with.
+const DefaultRating = 1200
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+// UpdatePassword sets a new password and invalidates every outstanding reset
+// link, which was mailed for the old one.
+func (s *UserRepositoryImpl) UpdatePassword(ctx context.Context, userID uint, passwordHash string) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		if err := invalidateResetTokens(tx, userID); err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Update("password", passwordHash).Error
+	})
+}
+
+// CreatePasswordResetToken stores a new reset token and invalidates the ones
+// issued before it, so only the latest link mailed to the user works.
+func (s *UserRepositoryImpl) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		if err := invalidateResetTokens(tx, token.UserID); err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (s *UserRepositoryImpl) GetPasswordResetTokenByHash(ctx context.Context, hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := s.DB.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &token, err
+}
+
+// ResetPassword consumes the reset token and sets the new password in one
+// transaction. It reports false when the token had already been used or was
+// replaced by a newer one.
+func (s *UserRepositoryImpl) ResetPassword(ctx context.Context, tokenID uint, userID uint, passwordHash string) (bool, error) {
+	used := false
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND user_id = ? AND used_at IS NULL", tokenID, userID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		used = true
+
+		if err := invalidateResetTokens(tx, userID); err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Update("password", passwordHash).Error
+	})
+
+	return used, err
+}
+
+// invalidateResetTokens marks the user's unused reset tokens as used.
+func invalidateResetTokens(tx *gorm.DB, userID uint) error {
+	return tx.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PracticeRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *PracticeRepositoryImpl) CreateSession(ctx context.Context, session *models.PracticeSession) (*models.PracticeSession, error) {
+	if err := s.DB.WithContext(ctx).Create(session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *PracticeRepositoryImpl) GetSession(ctx context.Context, id uint) (*models.PracticeSession, error) {
+	var session models.PracticeSession
+	err := s.DB.WithContext(ctx).First(&session, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &session, err
+}
+
+// MarkSolved records the first solve of a session. It reports false when the
+// session was already solved.
+func (s *PracticeRepositoryImpl) MarkSolved(ctx context.Context, sessionID uint, solvedAt time.Time) (bool, error) {
+	result := s.DB.WithContext(ctx).
+		Model(&models.PracticeSession{}).
+		Where("id = ? AND solved_at IS NULL", sessionID).
+		Update("solved_at", solvedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// SavePersonalBest inserts the personal best or replaces the existing one if
+// the new time is faster. It reports whether a new best was stored.
+func (s *PracticeRepositoryImpl) SavePersonalBest(ctx context.Context, best *models.PersonalBest) (bool, error) {
+	result := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "problem_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"submission_id", "language_id", "best_time_ms", "achieved_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "personal_bests.best_time_ms > excluded.best_time_ms"},
+		}},
+	}).Create(best)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (s *PracticeRepositoryImpl) ListPersonalBests(ctx context.Context, userID uint) ([]models.PersonalBest, error) {
+	var bests []models.PersonalBest
+	err := s.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("achieved_at DESC").
+		Find(&bests).Error
+
+	return bests, err
+}
+
+func (s *PracticeRepositoryImpl) SolveTimeStats(ctx context.Context, problemID uint) (int64, int64, error) {
+	var row struct {
+		Solvers  int64
+		MedianMs float64
+	}
+	err := s.DB.WithContext(ctx).
+		Model(&models.PersonalBest{}).
+		Select("COUNT(*) AS solvers, COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY best_time_ms), 0) AS median_ms").
+		Where("problem_id = ?", problemID).
+		Scan(&row).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return row.Solvers, int64(row.MedianMs), nil
+}
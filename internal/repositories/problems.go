@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProblemRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *ProblemRepositoryImpl) GetByID(ctx context.Context, id uint) (*models.Problem, error) {
+	var problem models.Problem
+	err := s.DB.WithContext(ctx).
+		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Templates").
+		Preload("Statements").
+		First(&problem, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &problem, err
+}
+
+func (s *ProblemRepositoryImpl) GetRandomPublished(ctx context.Context) (*models.Problem, error) {
+	var problem models.Problem
+	err := s.DB.WithContext(ctx).
+		Where("is_published = ?", true).
+		Order("RANDOM()").
+		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Statements").
+		First(&problem).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &problem, err
+}
+
+// ListPublished returns published problems without test cases, templates or
+// statements; translations only carry their title.
+func (s *ProblemRepositoryImpl) ListPublished(ctx context.Context) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := s.DB.WithContext(ctx).
+		Where("is_published = ?", true).
+		Omit("statement", "checker_source").
+		Preload("Statements", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "problem_id", "locale", "title")
+		}).
+		Order("id ASC").
+		Find(&problems).Error
+
+	return problems, err
+}
+
+// ListAll returns every problem, published or not, with test cases,
+// templates and translations.
+func (s *ProblemRepositoryImpl) ListAll(ctx context.Context) ([]models.Problem, error) {
+	var problems []models.Problem
+	err := s.DB.WithContext(ctx).
+		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Templates").
+		Preload("Statements").
+		Order("id ASC").
+		Find(&problems).Error
+
+	return problems, err
+}
+
+// Seed creates the problems whose slug does not exist yet, together with
+// their test cases and templates, and returns how many were created.
+// Existing problems are left untouched so edits made by hand survive.
+func (s *ProblemRepositoryImpl) Seed(ctx context.Context, problems []models.Problem) (int, error) {
+	created := 0
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		for i := range problems {
+			var count int64
+			if err := tx.Model(&models.Problem{}).Where("slug = ?", problems[i].Slug).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			if err := createVersioned(tx, &problems[i], nil); err != nil {
+				return err
+			}
+			created++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+// Create stores a new problem as version 1.
+func (s *ProblemRepositoryImpl) Create(ctx context.Context, problem *models.Problem, authorID *uint) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		return createVersioned(tx, problem, authorID)
+	})
+}
+
+// Update replaces the content of a problem and stores it as a new version.
+// It fails with ErrConflict when the problem is no longer at
+// expectedVersion, i.e. someone else saved it in the meantime.
+func (s *ProblemRepositoryImpl) Update(ctx context.Context, problem *models.Problem, expectedVersion int, authorID *uint) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		var existing models.Problem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "version", "is_published", "created_at").
+			First(&existing, problem.ID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Version != expectedVersion {
+			return ErrConflict
+		}
+		return replaceVersioned(tx, problem, &existing, authorID)
+	})
+}
+
+// Import creates the problem or, if its slug exists, replaces the existing
+// problem's content with a new version. It reports whether the problem was
+// created. The published state of an existing problem is kept.
+func (s *ProblemRepositoryImpl) Import(ctx context.Context, problem *models.Problem, authorID *uint) (bool, error) {
+	created := false
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		var existing models.Problem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "version", "is_published", "created_at").
+			Where("slug = ?", problem.Slug).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			created = true
+			return createVersioned(tx, problem, authorID)
+		}
+		if err != nil {
+			return err
+		}
+		problem.ID = existing.ID
+		return replaceVersioned(tx, problem, &existing, authorID)
+	})
+	return created, err
+}
+
+func (s *ProblemRepositoryImpl) SetPublished(ctx context.Context, id uint, published bool) error {
+	result := s.DB.WithContext(ctx).Model(&models.Problem{}).
+		Where("id = ?", id).
+		Update("is_published", published)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListVersions returns the versions of a problem, newest first, without
+// their snapshots.
+func (s *ProblemRepositoryImpl) ListVersions(ctx context.Context, problemID uint) ([]models.ProblemVersion, error) {
+	var versions []models.ProblemVersion
+	err := s.DB.WithContext(ctx).
+		Omit("snapshot").
+		Where("problem_id = ?", problemID).
+		Order("version DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+func (s *ProblemRepositoryImpl) GetVersion(ctx context.Context, problemID uint, version int) (*models.ProblemVersion, error) {
+	var v models.ProblemVersion
+	err := s.DB.WithContext(ctx).
+		Where("problem_id = ? AND version = ?", problemID, version).
+		First(&v).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return &v, err
+}
+
+func createVersioned(tx *gorm.DB, problem *models.Problem, authorID *uint) error {
+	resetProblemIDs(problem)
+	problem.Version = 1
+	if err := tx.Create(problem).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrConflict
+		}
+		return err
+	}
+	return saveVersion(tx, problem, authorID)
+}
+
+// replaceVersioned overwrites existing with problem, replacing test cases,
+// templates and translations, and stores the result as the next version.
+func replaceVersioned(tx *gorm.DB, problem *models.Problem, existing *models.Problem, authorID *uint) error {
+	resetProblemIDs(problem)
+	problem.ID = existing.ID
+	problem.Version = existing.Version + 1
+	problem.IsPublished = existing.IsPublished
+	problem.CreatedAt = existing.CreatedAt
+	for i := range problem.TestCases {
+		problem.TestCases[i].ProblemID = existing.ID
+	}
+	for i := range problem.Templates {
+		problem.Templates[i].ProblemID = existing.ID
+	}
+	for i := range problem.Statements {
+		problem.Statements[i].ProblemID = existing.ID
+	}
+
+	if err := tx.Where("problem_id = ?", existing.ID).Delete(&models.TestCase{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("problem_id = ?", existing.ID).Delete(&models.ProblemTemplate{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("problem_id = ?", existing.ID).Delete(&models.ProblemStatement{}).Error; err != nil {
+		return err
+	}
+	// Save는 0/false 값도 그대로 덮어씀
+	err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(problem).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrConflict
+	}
+	if err != nil {
+		return err
+	}
+	return saveVersion(tx, problem, authorID)
+}
+
+// resetProblemIDs clears ids that came from a request or another database.
+func resetProblemIDs(problem *models.Problem) {
+	problem.ID = 0
+	for i := range problem.TestCases {
+		problem.TestCases[i].ID = 0
+		problem.TestCases[i].ProblemID = 0
+	}
+	for i := range problem.Templates {
+		problem.Templates[i].ID = 0
+		problem.Templates[i].ProblemID = 0
+	}
+	for i := range problem.Statements {
+		problem.Statements[i].ID = 0
+		problem.Statements[i].ProblemID = 0
+	}
+}
+
+func saveVersion(tx *gorm.DB, problem *models.Problem, authorID *uint) error {
+	snapshot, err := json.Marshal(models.NewProblemSnapshot(problem))
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.ProblemVersion{
+		ProblemID: problem.ID,
+		Version:   problem.Version,
+		AuthorID:  authorID,
+		Snapshot:  string(snapshot),
+	}).Error
+}
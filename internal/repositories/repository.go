@@ -16,8 +16,16 @@ var (
 )
 
 type Repository struct {
-	UserRepository UserRepositoryInterface
-	RoleRepository RoleRepositoryInterface
+	UserRepository       UserRepositoryInterface
+	RoleRepository       RoleRepositoryInterface
+	ProblemRepository    ProblemRepositoryInterface
+	SubmissionRepository SubmissionRepositoryInterface
+	PracticeRepository   PracticeRepositoryInterface
+	SessionRepository    SessionRepositoryInterface
+	TwoFactorRepository  TwoFactorRepositoryInterface
+	MatchRepository      MatchRepositoryInterface
+	APIKeyRepository     APIKeyRepositoryInterface
+	AuditLogRepository   AuditLogRepositoryInterface
 }
 
 type UserRepositoryInterface interface {
@@ -26,16 +34,115 @@ type UserRepositoryInterface interface {
 	Create(context.Context, *models.User) (*models.User, error)
 	Activate(context.Context, string) error
 	Delete(context.Context, int64) error
+	UpdatePassword(context.Context, uint, string) error
+	CreatePasswordResetToken(context.Context, *models.PasswordResetToken) error
+	GetPasswordResetTokenByHash(context.Context, string) (*models.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenID uint, userID uint, passwordHash string) (bool, error)
+	UsernameExists(context.Context, string) (bool, error)
+	GetIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	CreateIdentity(context.Context, *models.UserIdentity) error
+	CreateWithIdentity(context.Context, *models.User, *models.UserIdentity) (*models.User, error)
+	GetByUsername(context.Context, string) (*models.User, error)
+	UpdateProfile(ctx context.Context, id uint, updates map[string]interface{}) error
+	ScheduleDeletion(ctx context.Context, userID uint, at time.Time) error
+	CancelDeletion(context.Context, uint) (bool, error)
+	ListDueForDeletion(context.Context, time.Time) ([]uint, error)
+	ListIdentities(context.Context, uint) ([]models.UserIdentity, error)
+	UpdateRole(ctx context.Context, userID uint, roleID uint) error
 }
 
 type RoleRepositoryInterface interface {
+	GetByID(context.Context, uint) (*models.Role, error)
 	GetByName(context.Context, string) (*models.Role, error)
+	Seed(context.Context, map[string][]string) error
+}
+
+type ProblemRepositoryInterface interface {
+	GetByID(context.Context, uint) (*models.Problem, error)
+	GetRandomPublished(context.Context) (*models.Problem, error)
+	ListPublished(context.Context) ([]models.Problem, error)
+	Seed(context.Context, []models.Problem) (int, error)
+	ListAll(context.Context) ([]models.Problem, error)
+	Create(ctx context.Context, problem *models.Problem, authorID *uint) error
+	Update(ctx context.Context, problem *models.Problem, expectedVersion int, authorID *uint) error
+	Import(ctx context.Context, problem *models.Problem, authorID *uint) (bool, error)
+	SetPublished(ctx context.Context, id uint, published bool) error
+	ListVersions(context.Context, uint) ([]models.ProblemVersion, error)
+	GetVersion(ctx context.Context, problemID uint, version int) (*models.ProblemVersion, error)
+}
+
+type SubmissionRepositoryInterface interface {
+	Create(context.Context, *models.Submission) (*models.Submission, error)
+	GetByID(context.Context, uint) (*models.Submission, error)
+	List(context.Context, SubmissionFilter) ([]models.Submission, int64, error)
+	CountByVerdict(context.Context, uint) (map[string]int64, error)
+	FavouriteLanguage(ctx context.Context, userID uint) (languageID int, ok bool, err error)
+	ListByUser(context.Context, uint) ([]models.Submission, error)
+}
+
+type MatchRepositoryInterface interface {
+	RecordMatch(ctx context.Context, match *models.Match, rate func(map[uint]int) map[uint]int) error
+	UserStats(ctx context.Context, userID uint) (played int64, wins int64, err error)
+	ListByUser(context.Context, uint) ([]models.Match, error)
+	RecomputeRatings(ctx context.Context, rate func(ratings map[uint]int, match *models.Match) map[uint]int) (int, error)
+}
+
+type APIKeyRepositoryInterface interface {
+	Create(context.Context, *models.APIKey) (*models.APIKey, error)
+	ListByUser(context.Context, uint) ([]models.APIKey, error)
+	CountByUser(context.Context, uint) (int64, error)
+	GetByHash(context.Context, string) (*models.APIKey, error)
+	Delete(ctx context.Context, userID uint, id uint) error
+	TouchLastUsed(ctx context.Context, id uint, t time.Time) error
+}
+
+type AuditLogRepositoryInterface interface {
+	CreateBatch(context.Context, []models.AuditLog) error
+	List(context.Context, AuditLogFilter) ([]models.AuditLog, int64, error)
+}
+
+type PracticeRepositoryInterface interface {
+	CreateSession(context.Context, *models.PracticeSession) (*models.PracticeSession, error)
+	GetSession(context.Context, uint) (*models.PracticeSession, error)
+	MarkSolved(ctx context.Context, sessionID uint, solvedAt time.Time) (bool, error)
+	SavePersonalBest(context.Context, *models.PersonalBest) (bool, error)
+	ListPersonalBests(ctx context.Context, userID uint) ([]models.PersonalBest, error)
+	SolveTimeStats(ctx context.Context, problemID uint) (solvers int64, medianMs int64, err error)
+}
+
+type SessionRepositoryInterface interface {
+	CreateSession(context.Context, *models.AuthSession) (*models.AuthSession, error)
+	GetSession(context.Context, string) (*models.AuthSession, error)
+	RevokeSession(context.Context, string) error
+	RevokeUserSessions(context.Context, uint) ([]string, error)
+	CreateRefreshToken(context.Context, *models.RefreshToken) error
+	GetRefreshTokenByHash(context.Context, string) (*models.RefreshToken, error)
+	UseRefreshToken(context.Context, uint) (bool, error)
+}
+
+type TwoFactorRepositoryInterface interface {
+	GetTOTP(context.Context, uint) (*models.UserTOTP, error)
+	SavePendingTOTP(ctx context.Context, userID uint, secret string) error
+	ConfirmTOTP(ctx context.Context, userID uint, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID uint, step int64) (bool, error)
+	DisableTOTP(context.Context, uint) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(context.Context, uint) (int64, error)
 }
 
 func NewRepository(db *gorm.DB) Repository {
 	return Repository{
-		UserRepository: &UserRepositoryImpl{db},
-		RoleRepository: &RoleRepositoryImpl{db},
+		UserRepository:       &UserRepositoryImpl{db},
+		RoleRepository:       &RoleRepositoryImpl{db},
+		ProblemRepository:    &ProblemRepositoryImpl{db},
+		SubmissionRepository: &SubmissionRepositoryImpl{db},
+		PracticeRepository:   &PracticeRepositoryImpl{db},
+		SessionRepository:    &SessionRepositoryImpl{db},
+		TwoFactorRepository:  &TwoFactorRepositoryImpl{db},
+		MatchRepository:      &MatchRepositoryImpl{db},
+		APIKeyRepository:     &APIKeyRepositoryImpl{db},
+		AuditLogRepository:   &AuditLogRepositoryImpl{db},
 	}
 }
 
@@ -12,11 +12,50 @@ type RoleRepositoryImpl struct {
 	DB *gorm.DB
 }
 
+func (s *RoleRepositoryImpl) GetByID(ctx context.Context, id uint) (*models.Role, error) {
+	var role models.Role
+	err := s.DB.WithContext(ctx).Preload("Permissions").First(&role, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("role not found")
+	}
+	return &role, err
+}
+
 func (s *RoleRepositoryImpl) GetByName(ctx context.Context, name string) (*models.Role, error) {
 	var role models.Role
-	err := s.DB.WithContext(ctx).Where("name=?", name).First(&role).Error
+	err := s.DB.WithContext(ctx).Preload("Permissions").Where("name=?", name).First(&role).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New("user not found")
+		return nil, errors.New("role not found")
 	}
 	return &role, err
 }
+
+// Seed creates the given roles and permissions if they are missing and grants
+// each role its listed permissions. It never revokes existing grants.
+func (s *RoleRepositoryImpl) Seed(ctx context.Context, rolePermissions map[string][]string) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for roleName, permissionNames := range rolePermissions {
+			role := models.Role{Name: roleName}
+			if err := tx.Where(models.Role{Name: roleName}).FirstOrCreate(&role).Error; err != nil {
+				return err
+			}
+
+			permissions := make([]models.Permission, 0, len(permissionNames))
+			for _, name := range permissionNames {
+				permission := models.Permission{Name: name}
+				if err := tx.Where(models.Permission{Name: name}).FirstOrCreate(&permission).Error; err != nil {
+					return err
+				}
+				permissions = append(permissions, permission)
+			}
+
+			if len(permissions) == 0 {
+				continue
+			}
+			if err := tx.Model(&role).Association("Permissions").Append(permissions); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *SessionRepositoryImpl) CreateSession(ctx context.Context, session *models.AuthSession) (*models.AuthSession, error) {
+	if err := s.DB.WithContext(ctx).Create(session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *SessionRepositoryImpl) GetSession(ctx context.Context, id string) (*models.AuthSession, error) {
+	var session models.AuthSession
+	err := s.DB.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &session, err
+}
+
+func (s *SessionRepositoryImpl) RevokeSession(ctx context.Context, id string) error {
+	return s.DB.WithContext(ctx).
+		Model(&models.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserSessions revokes every active session of the user and returns the
+// ids of the sessions it revoked.
+func (s *SessionRepositoryImpl) RevokeUserSessions(ctx context.Context, userID uint) ([]string, error) {
+	var ids []string
+	err := withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		err := tx.Model(&models.AuthSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		return tx.Model(&models.AuthSession{}).
+			Where("id IN ?", ids).
+			Update("revoked_at", time.Now()).Error
+	})
+
+	return ids, err
+}
+
+func (s *SessionRepositoryImpl) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return s.DB.WithContext(ctx).Create(token).Error
+}
+
+func (s *SessionRepositoryImpl) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := s.DB.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &token, err
+}
+
+// UseRefreshToken marks the token as rotated. It reports false when the token
+// had already been used, which means it is being replayed.
+func (s *SessionRepositoryImpl) UseRefreshToken(ctx context.Context, id uint) (bool, error) {
+	result := s.DB.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+)
+
+// SubmissionFilter narrows down SubmissionRepository.List. Zero values are
+// ignored.
+type SubmissionFilter struct {
+	UserID     uint
+	ProblemID  uint
+	LanguageID int
+	Verdict    string
+	Limit      int
+	Offset     int
+}
+
+type SubmissionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *SubmissionRepositoryImpl) Create(ctx context.Context, submission *models.Submission) (*models.Submission, error) {
+	if err := s.DB.WithContext(ctx).Create(submission).Error; err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+func (s *SubmissionRepositoryImpl) GetByID(ctx context.Context, id uint) (*models.Submission, error) {
+	var submission models.Submission
+	err := s.DB.WithContext(ctx).First(&submission, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &submission, err
+}
+
+// List returns a page of submissions, newest first, without their source
+// code, together with the total number of matching submissions.
+func (s *SubmissionRepositoryImpl) List(ctx context.Context, filter SubmissionFilter) ([]models.Submission, int64, error) {
+	query := s.DB.WithContext(ctx).Model(&models.Submission{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ProblemID != 0 {
+		query = query.Where("problem_id = ?", filter.ProblemID)
+	}
+	if filter.LanguageID != 0 {
+		query = query.Where("language_id = ?", filter.LanguageID)
+	}
+	if filter.Verdict != "" {
+		query = query.Where("verdict = ?", filter.Verdict)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var submissions []models.Submission
+	err := query.
+		Omit("source_code").
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&submissions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return submissions, total, nil
+}
+
+// CountByVerdict returns the number of submissions of a problem per verdict.
+func (s *SubmissionRepositoryImpl) CountByVerdict(ctx context.Context, problemID uint) (map[string]int64, error) {
+	var rows []struct {
+		Verdict string
+		Count   int64
+	}
+	err := s.DB.WithContext(ctx).
+		Model(&models.Submission{}).
+		Select("verdict, COUNT(*) AS count").
+		Where("problem_id = ?", problemID).
+		Group("verdict").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Verdict] = row.Count
+	}
+	return counts, nil
+}
+
+// FavouriteLanguage returns the language the user submitted most often. ok is
+// false when the user has no submissions.
+func (s *SubmissionRepositoryImpl) FavouriteLanguage(ctx context.Context, userID uint) (languageID int, ok bool, err error) {
+	var rows []struct {
+		LanguageID int
+		Count      int64
+	}
+	err = s.DB.WithContext(ctx).
+		Model(&models.Submission{}).
+		Select("language_id, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("language_id").
+		Order("count DESC, language_id").
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return 0, false, err
+	}
+
+	return rows[0].LanguageID, true, nil
+}
+
+// ListByUser returns every submission of the user, oldest first, including
+// the source code.
+func (s *SubmissionRepositoryImpl) ListByUser(ctx context.Context, userID uint) ([]models.Submission, error) {
+	var submissions []models.Submission
+	err := s.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id").
+		Find(&submissions).Error
+	return submissions, err
+}
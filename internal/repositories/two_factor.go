@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (s *TwoFactorRepositoryImpl) GetTOTP(ctx context.Context, userID uint) (*models.UserTOTP, error) {
+	var totp models.UserTOTP
+	err := s.DB.WithContext(ctx).Where("user_id = ?", userID).First(&totp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &totp, err
+}
+
+// SavePendingTOTP starts (or restarts) an enrollment with a new secret.
+func (s *TwoFactorRepositoryImpl) SavePendingTOTP(ctx context.Context, userID uint, secret string) error {
+	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"secret": secret, "confirmed_at": nil, "last_used_step": 0}),
+	}).Create(&models.UserTOTP{UserID: userID, Secret: secret}).Error
+}
+
+// ConfirmTOTP enables 2FA and replaces the user's recovery codes.
+func (s *TwoFactorRepositoryImpl) ConfirmTOTP(ctx context.Context, userID uint, step int64, recoveryCodeHashes []string) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		err := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step}).Error
+		if err != nil {
+			return err
+		}
+
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+// UseTOTPStep records the time step of an accepted code. It reports false
+// when that step, or a later one, was already used.
+func (s *TwoFactorRepositoryImpl) UseTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := s.DB.WithContext(ctx).
+		Model(&models.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (s *TwoFactorRepositoryImpl) DisableTOTP(ctx context.Context, userID uint) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTOTP{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	})
+}
+
+func (s *TwoFactorRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	return withTx(s.DB, ctx, func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+// UseRecoveryCode consumes an unused recovery code. It reports false when no
+// such code exists.
+func (s *TwoFactorRepositoryImpl) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	result := s.DB.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (s *TwoFactorRepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := s.DB.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	codes := make([]models.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
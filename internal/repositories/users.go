@@ -14,9 +14,9 @@ type UserRepositoryImpl struct {
 
 func (s *UserRepositoryImpl) GetByID(ctx context.Context, id int) (*models.User, error) {
 	var user models.User
-	err := s.DB.WithContext(ctx).First(&user, id).Error
+	err := s.DB.WithContext(ctx).Preload("Role").First(&user, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New("user not found")
+		return nil, ErrNotFound
 	}
 
 	return &user, err
@@ -24,14 +24,45 @@ func (s *UserRepositoryImpl) GetByID(ctx context.Context, id int) (*models.User,
 
 func (s *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := s.DB.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := s.DB.WithContext(ctx).Preload("Role").Where("email = ?", email).First(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New("user not found")
+		return nil, ErrNotFound
 	}
 
 	return &user, err
 }
 
+func (s *UserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := s.DB.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &user, err
+}
+
+// UpdateProfile updates the given profile columns. A taken username is
+// reported as ErrConflict.
+func (s *UserRepositoryImpl) UpdateProfile(ctx context.Context, id uint, updates map[string]interface{}) error {
+	err := s.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(updates).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (s *UserRepositoryImpl) UpdateRole(ctx context.Context, userID uint, roleID uint) error {
+	result := s.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("role_id", roleID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 func (s *UserRepositoryImpl) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	if err := s.DB.WithContext(ctx).Create(user).Error; err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
@@ -52,11 +83,3 @@ func (s *UserRepositoryImpl) Activate(ctx context.Context, email string) error {
 	}
 	return nil
 }
-
-func (s *UserRepositoryImpl) Delete(ctx context.Context, id int64) error {
-	err := s.DB.WithContext(ctx).Delete(&models.User{}, id).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return errors.New("user not found")
-	}
-	return err
-}
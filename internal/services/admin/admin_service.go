@@ -0,0 +1,155 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"go.uber.org/zap"
+)
+
+var (
+	instance AdminService
+	once     sync.Once
+
+	ErrUserNotFound        = errors.New("user not found")
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrCannotChangeOwnRole = errors.New("you cannot change your own role")
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+type AuditLogPage struct {
+	Logs    []*mapper.MappedAuditLog `json:"logs"`
+	Total   int64                    `json:"total"`
+	Page    int                      `json:"page"`
+	PerPage int                      `json:"per_page"`
+}
+
+// RoleChange describes a role change for the audit log.
+type RoleChange struct {
+	UserID  uint   `json:"user_id"`
+	OldRole string `json:"old_role"`
+	NewRole string `json:"new_role"`
+}
+
+type AdminService struct {
+	userRepository     repositories.UserRepositoryInterface
+	roleRepository     repositories.RoleRepositoryInterface
+	auditLogRepository repositories.AuditLogRepositoryInterface
+	problemRepository  repositories.ProblemRepositoryInterface
+	matchRepository    repositories.MatchRepositoryInterface
+	userStore          cache.UsersRedisStoreInterface
+	logger             *zap.SugaredLogger
+}
+
+// NewAdminService creates the admin service. userStore may be nil when Redis
+// is disabled.
+func NewAdminService(
+	ur repositories.UserRepositoryInterface,
+	rr repositories.RoleRepositoryInterface,
+	ar repositories.AuditLogRepositoryInterface,
+	pr repositories.ProblemRepositoryInterface,
+	mr repositories.MatchRepositoryInterface,
+	userStore cache.UsersRedisStoreInterface,
+	logger *zap.SugaredLogger,
+) AdminService {
+	once.Do(func() {
+		instance = AdminService{
+			userRepository:     ur,
+			roleRepository:     rr,
+			auditLogRepository: ar,
+			problemRepository:  pr,
+			matchRepository:    mr,
+			userStore:          userStore,
+			logger:             logger,
+		}
+	})
+	return instance
+}
+
+func (as *AdminService) ListAuditLogs(ctx context.Context, query dtos.AuditLogListQuery) (*AuditLogPage, error) {
+	page, perPage := query.Page, query.PerPage
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	logs, total, err := as.auditLogRepository.List(ctx, repositories.AuditLogFilter{
+		ActorID: query.ActorID,
+		Action:  query.Action,
+		Since:   query.Since,
+		Until:   query.Until,
+		Limit:   perPage,
+		Offset:  (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := make([]*mapper.MappedAuditLog, len(logs))
+	for i := range logs {
+		mapped[i] = mapper.AuditLogMapper(&logs[i])
+	}
+
+	return &AuditLogPage{Logs: mapped, Total: total, Page: page, PerPage: perPage}, nil
+}
+
+// ChangeUserRole assigns a role to a user. Admins cannot change their own
+// role so the last admin cannot lock everyone out by accident.
+func (as *AdminService) ChangeUserRole(ctx context.Context, actorID uint, userID uint, roleName string) (*RoleChange, error) {
+	if actorID == userID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	user, err := as.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	role, err := as.roleRepository.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, roleName)
+	}
+
+	change := &RoleChange{UserID: userID, NewRole: role.Name}
+	if user.Role != nil {
+		change.OldRole = user.Role.Name
+	}
+	if user.RoleID == role.ID {
+		return change, nil
+	}
+
+	if err := as.userRepository.UpdateRole(ctx, userID, role.ID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// 권한 검사는 캐시된 사용자의 role_id를 사용하므로 캐시 삭제
+	if as.userStore != nil {
+		if err := as.userStore.Delete(ctx, int(userID)); err != nil {
+			as.logger.Warnw("failed to invalidate cached user", "userID", userID, "error", err)
+		}
+	}
+
+	as.logger.Infow("user role changed", "actorID", actorID, "userID", userID, "from", change.OldRole, "to", change.NewRole)
+	return change, nil
+}
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/game"
+	"github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+)
+
+// ProblemDocument is the JSON import/export format of a problem. Unlike the
+// API response it includes the checker and reference solution.
+type ProblemDocument = models.ProblemSnapshot
+
+type ImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// CreateUser creates an activated account with the given role, skipping
+// email verification.
+func (as *AdminService) CreateUser(ctx context.Context, dto dtos.SignupRequestDto, roleName string) (*mapper.MappedUser, error) {
+	if err := utils.ValidatePassword(dto.Password, dto.Username, dto.Email); err != nil {
+		return nil, err
+	}
+
+	role, err := as.roleRepository.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, roleName)
+	}
+
+	hashed, err := utils.HashPassword(dto.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.userRepository.Create(ctx, &models.User{
+		Username: dto.Username,
+		Email:    dto.Email,
+		Password: hashed,
+		RoleID:   role.ID,
+		Role:     role,
+		IsActive: true,
+		Rating:   models.DefaultRating,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as.logger.Infow("user created by operator", "userID", user.ID, "role", role.Name)
+	return mapper.UserMapper(user), nil
+}
+
+// ExportProblems returns every problem, published or not.
+func (as *AdminService) ExportProblems(ctx context.Context) ([]ProblemDocument, error) {
+	problems, err := as.problemRepository.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]ProblemDocument, len(problems))
+	for i := range problems {
+		docs[i] = *models.NewProblemSnapshot(&problems[i])
+	}
+	return docs, nil
+}
+
+// ImportProblems validates every document before creating or replacing
+// problems by slug, so a bad file changes nothing.
+func (as *AdminService) ImportProblems(ctx context.Context, docs []ProblemDocument) (*ImportResult, error) {
+	imported := make([]*models.Problem, len(docs))
+	for i := range docs {
+		imported[i] = docs[i].ToProblem()
+		if err := problems.Validate(imported[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ImportResult{}
+	for _, problem := range imported {
+		created, err := as.problemRepository.Import(ctx, problem, nil)
+		if err != nil {
+			return result, fmt.Errorf("failed to import %q: %w", problem.Slug, err)
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	as.logger.Infow("problems imported", "created", result.Created, "updated", result.Updated)
+	return result, nil
+}
+
+// RecomputeRatings rebuilds every Elo rating from the match history, e.g.
+// after fixing a rating bug or removing matches. It returns the number of
+// matches replayed. Cached users keep their old rating until the cache
+// expires or is flushed.
+func (as *AdminService) RecomputeRatings(ctx context.Context) (int, error) {
+	replayed, err := as.matchRepository.RecomputeRatings(ctx, func(ratings map[uint]int, match *models.Match) map[uint]int {
+		if match.WinnerID == nil {
+			return ratings
+		}
+		return game.UpdateRatings(ratings, *match.WinnerID)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	as.logger.Infow("ratings recomputed", "matches", replayed)
+	return replayed, nil
+}
@@ -0,0 +1,191 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"go.uber.org/zap"
+)
+
+var (
+	instance APIKeysService
+	once     sync.Once
+
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrInvalidAPIKey      = errors.New("invalid api key")
+	ErrTooManyAPIKeys     = errors.New("api key limit reached")
+	ErrInvalidAPIKeyInput = errors.New("invalid api key request")
+)
+
+const (
+	// 사용자당 최대 키 개수
+	MaxKeysPerUser     = 10
+	maxKeyNameLength   = 64
+	maxExpiresInDays   = 365
+	lastUsedResolution = time.Minute
+)
+
+// CreatedAPIKey is returned once, when the key is created. Key is never
+// shown again.
+type CreatedAPIKey struct {
+	Key    string               `json:"key"`
+	APIKey *mapper.MappedAPIKey `json:"api_key"`
+}
+
+type APIKeysService struct {
+	apiKeyRepository repositories.APIKeyRepositoryInterface
+	logger           *zap.SugaredLogger
+}
+
+func NewAPIKeysService(ar repositories.APIKeyRepositoryInterface, logger *zap.SugaredLogger) APIKeysService {
+	once.Do(func() {
+		instance = APIKeysService{
+			apiKeyRepository: ar,
+			logger:           logger,
+		}
+	})
+	return instance
+}
+
+func (as *APIKeysService) CreateKey(ctx context.Context, userID uint, dto dtos.CreateAPIKeyRequestDto) (*CreatedAPIKey, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxKeyNameLength {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", ErrInvalidAPIKeyInput, maxKeyNameLength)
+	}
+
+	scopes, err := normalizeScopes(dto.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if dto.ExpiresInDays != nil {
+		days := *dto.ExpiresInDays
+		if days < 1 || days > maxExpiresInDays {
+			return nil, fmt.Errorf("%w: expires_in_days must be between 1 and %d", ErrInvalidAPIKeyInput, maxExpiresInDays)
+		}
+		t := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		expiresAt = &t
+	}
+
+	count, err := as.apiKeyRepository.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxKeysPerUser {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	key, prefix, hash, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := as.apiKeyRepository.Create(ctx, &models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	as.logger.Infow("api key created", "userID", userID, "keyID", created.ID, "scopes", scopes)
+	return &CreatedAPIKey{Key: key, APIKey: mapper.APIKeyMapper(created)}, nil
+}
+
+func (as *APIKeysService) ListKeys(ctx context.Context, userID uint) ([]*mapper.MappedAPIKey, error) {
+	keys, err := as.apiKeyRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := make([]*mapper.MappedAPIKey, len(keys))
+	for i := range keys {
+		mapped[i] = mapper.APIKeyMapper(&keys[i])
+	}
+	return mapped, nil
+}
+
+func (as *APIKeysService) DeleteKey(ctx context.Context, userID uint, keyID uint) error {
+	if err := as.apiKeyRepository.Delete(ctx, userID, keyID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	as.logger.Infow("api key deleted", "userID", userID, "keyID", keyID)
+	return nil
+}
+
+// Authenticate resolves a key from the X-API-Key header. Expired and unknown
+// keys are both reported as ErrInvalidAPIKey.
+func (as *APIKeysService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	if !strings.HasPrefix(key, utils.APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := as.apiKeyRepository.GetByHash(ctx, utils.HashToken(key))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// 매 요청마다 쓰지 않도록 분 단위로만 갱신
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := as.apiKeyRepository.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			as.logger.Warnw("failed to update api key last use", "keyID", apiKey.ID, "error", err)
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
+
+func normalizeScopes(requested []string) ([]string, error) {
+	known := make(map[string]bool, len(models.APIKeyScopes))
+	for _, s := range models.APIKeyScopes {
+		known[s] = true
+	}
+
+	seen := make(map[string]bool)
+	scopes := make([]string, 0, len(requested))
+	for _, s := range requested {
+		s = strings.TrimSpace(s)
+		if !known[s] {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKeyInput, s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKeyInput)
+	}
+
+	sort.Strings(scopes)
+	return scopes, nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+)
+
+// 탈퇴 요청 후 계정이 익명화되기까지의 유예 기간
+const AccountDeletionGracePeriod = 14 * 24 * time.Hour
+
+var ErrDeletionAlreadyScheduled = errors.New("account deletion is already scheduled")
+
+// ScheduleAccountDeletion re-authenticates the user and schedules the account
+// for anonymization after the grace period. Every session is revoked; signing
+// in again before the deadline cancels the deletion.
+func (us *AuthService) ScheduleAccountDeletion(ctx context.Context, userID uint, password, code, ip string) (time.Time, error) {
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if user.DeletionScheduledAt != nil {
+		return time.Time{}, ErrDeletionAlreadyScheduled
+	}
+	if err := us.checkReauthPassword(ctx, user, password, ip); err != nil {
+		return time.Time{}, err
+	}
+
+	enabled, err := us.TwoFactorEnabled(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if enabled {
+		if err := us.verifySecondFactor(ctx, userID, user.Email, code, ip); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	deleteAt := time.Now().Add(AccountDeletionGracePeriod)
+	if err := us.userRepository.ScheduleDeletion(ctx, userID, deleteAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+	us.DeleteSession(ctx, int(userID))
+
+	us.logger.Infow("account deletion scheduled", "userID", userID, "deleteAt", deleteAt)
+	return deleteAt, us.RevokeUserSessions(ctx, userID)
+}
+
+// cancelAccountDeletion is called when a user with a pending deletion signs
+// in again.
+func (us *AuthService) cancelAccountDeletion(ctx context.Context, user *mapper.MappedUser) error {
+	cancelled, err := us.userRepository.CancelDeletion(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	user.DeletionScheduledAt = nil
+	us.DeleteSession(ctx, int(user.ID))
+
+	if cancelled {
+		us.logger.Infow("account deletion cancelled by sign-in", "userID", user.ID)
+	}
+	return nil
+}
@@ -2,19 +2,21 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
 	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
 	"github.com/Dongmoon29/code_racer_api/internal/mapper"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
 	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 )
 
 var (
@@ -23,19 +25,61 @@ var (
 )
 
 type AuthService struct {
-	userRepository repositories.UserRepositoryInterface
-	roleRepository repositories.RoleRepositoryInterface
-	userStore      cache.UsersRedisStoreInterface
-	logger         *zap.SugaredLogger
+	userRepository      repositories.UserRepositoryInterface
+	roleRepository      repositories.RoleRepositoryInterface
+	sessionRepository   repositories.SessionRepositoryInterface
+	twoFactorRepository repositories.TwoFactorRepositoryInterface
+	userStore           cache.UsersRedisStoreInterface
+	sessionStore        cache.SessionRedisStoreInterface
+	rateLimits          cache.RateLimitStoreInterface
+	ticketStore         cache.TicketStoreInterface
+	loginAttempts       cache.LoginAttemptStoreInterface
+	audit               audit.Recorder
+	oauthProviders      map[string]OAuthProvider
+	mailer              mailer.Mailer
+	publicURL           string
+	appURL              string
+	logger              *zap.SugaredLogger
 }
 
-func NewAuthService(ur repositories.UserRepositoryInterface, rr repositories.RoleRepositoryInterface, us cache.UsersRedisStoreInterface, logger *zap.SugaredLogger) AuthService {
+// NewAuthService creates the auth service. userStore and sessionStore may be
+// nil when Redis is disabled. publicURL (the API) and appURL (the web client)
+// are the base URLs used in links sent by email. Sign-in lockouts are
+// recorded to recorder.
+func NewAuthService(
+	ur repositories.UserRepositoryInterface,
+	rr repositories.RoleRepositoryInterface,
+	sr repositories.SessionRepositoryInterface,
+	tr repositories.TwoFactorRepositoryInterface,
+	us cache.UsersRedisStoreInterface,
+	ss cache.SessionRedisStoreInterface,
+	rl cache.RateLimitStoreInterface,
+	ts cache.TicketStoreInterface,
+	la cache.LoginAttemptStoreInterface,
+	recorder audit.Recorder,
+	providers map[string]OAuthProvider,
+	m mailer.Mailer,
+	publicURL string,
+	appURL string,
+	logger *zap.SugaredLogger,
+) AuthService {
 	once.Do(func() {
 		instance = AuthService{
-			userRepository: ur,
-			roleRepository: rr,
-			userStore:      us,
-			logger:         logger,
+			userRepository:      ur,
+			roleRepository:      rr,
+			sessionRepository:   sr,
+			twoFactorRepository: tr,
+			userStore:           us,
+			sessionStore:        ss,
+			rateLimits:          rl,
+			ticketStore:         ts,
+			loginAttempts:       la,
+			audit:               recorder,
+			oauthProviders:      providers,
+			mailer:              m,
+			publicURL:           publicURL,
+			appURL:              appURL,
+			logger:              logger,
 		}
 	})
 	return instance
@@ -52,7 +96,7 @@ func (us *AuthService) GetUserByID(ctx context.Context, userID int) (*mapper.Map
 
 	user, err := us.userRepository.GetByID(ctx, userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, repositories.ErrNotFound) {
 			return nil, fmt.Errorf("user not found with ID: %d", userID)
 		}
 		return nil, err
@@ -63,18 +107,34 @@ func (us *AuthService) GetUserByID(ctx context.Context, userID int) (*mapper.Map
 	return mappedUser, nil
 }
 
-func (us *AuthService) FindAndVerifyUserByEmail(dto dtos.SigninRequestDto) (*mapper.MappedUser, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// FindAndVerifyUserByEmail checks sign-in credentials. Unknown emails and wrong
+// passwords fail the same way, and repeated failures lock the account or IP.
+func (us *AuthService) FindAndVerifyUserByEmail(ctx context.Context, dto dtos.SigninRequestDto, ip string) (*mapper.MappedUser, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
+
+	if err := us.checkLocked(ctx, dto.Email, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := us.userRepository.GetByEmail(ctx, dto.Email)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+		if errors.Is(err, repositories.ErrNotFound) {
+			compareDummyPassword(dto.Password)
+			return nil, us.loginFailed(ctx, dto.Email, ip)
 		}
 		return nil, err
 	}
 	if !utils.CheckPasswordHash(dto.Password, user.Password) {
-		return nil, fmt.Errorf("invalid password")
+		return nil, us.loginFailed(ctx, dto.Email, ip)
+	}
+	// 2FA 사용자는 두 번째 단계까지 통과해야 실패 횟수를 초기화
+	if enabled, err := us.TwoFactorEnabled(ctx, user.ID); err == nil && !enabled {
+		us.loginSucceeded(ctx, dto.Email)
+	}
+
+	if !user.IsActive {
+		return nil, ErrEmailNotVerified
 	}
 
 	mappedUser := mapper.UserMapper(user)
@@ -85,13 +145,20 @@ func (us *AuthService) FindAndVerifyUserByEmail(dto dtos.SigninRequestDto) (*map
 func (us *AuthService) CreateUser(dto dtos.SignupRequestDto) (*mapper.MappedUser, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	if err := utils.ValidateUsername(dto.Username); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidatePassword(dto.Password, dto.Username, dto.Email); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(dto.Password)
 
 	if err != nil {
 		return nil, err
 	}
 
-	role, err := us.roleRepository.GetByName(ctx, "user")
+	role, err := us.roleRepository.GetByName(ctx, models.RoleUser)
 	if err != nil {
 		log.Println("cannot find role with name user")
 		return nil, err
@@ -101,7 +168,7 @@ func (us *AuthService) CreateUser(dto dtos.SignupRequestDto) (*mapper.MappedUser
 		Username: dto.Username,
 		Password: hashedPassword,
 		Email:    dto.Email,
-		IsActive: true,
+		IsActive: false,
 		RoleID:   role.ID,
 		Role:     role,
 	}
@@ -112,16 +179,27 @@ func (us *AuthService) CreateUser(dto dtos.SignupRequestDto) (*mapper.MappedUser
 		return nil, err
 	}
 
+	// 메일 발송 실패 시에도 가입은 유지 (재전송 가능)
+	if err := us.SendVerificationEmail(ctx, createdUser); err != nil {
+		us.logger.Errorw("failed to send verification email", "userID", createdUser.ID, "error", err)
+	}
+
 	mappedUser := mapper.UserMapper(createdUser)
 
 	return mappedUser, nil
 }
 
 func (us *AuthService) DeleteSession(ctx context.Context, userID int) {
+	if us.userStore == nil {
+		return
+	}
 	us.userStore.Delete(ctx, userID)
 }
 
 func (us *AuthService) SaveSession(ctx context.Context, user *mapper.MappedUser) error {
+	if us.userStore == nil {
+		return nil
+	}
 	err := us.userStore.Set(ctx, user)
 	if err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+)
+
+const (
+	// 실패 횟수를 세는 구간 (첫 실패부터)
+	loginFailureWindow = 15 * time.Minute
+	maxAccountFailures = 5
+	maxIPFailures      = 20
+	// 계정 잠금은 반복될 때마다 두 배 (최대 24시간)
+	accountLockout    = 15 * time.Minute
+	maxAccountLockout = 24 * time.Hour
+	lockoutHistoryTTL = 24 * time.Hour
+	ipLockout         = 15 * time.Minute
+	// 이 횟수 이후의 실패 응답은 점점 늦게 반환
+	delayAfterFailures = 3
+	baseFailureDelay   = 500 * time.Millisecond
+	maxFailureDelay    = 5 * time.Second
+)
+
+var (
+	// 계정 존재 여부를 드러내지 않도록 동일한 메시지 사용
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("too many failed sign-in attempts, try again later")
+)
+
+// LockedError is returned while an account or IP is locked out.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string { return ErrAccountLocked.Error() }
+func (e *LockedError) Unwrap() error { return ErrAccountLocked }
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// compareDummyPassword spends the same bcrypt time as a real comparison so an
+// unknown email cannot be told apart by response time.
+func compareDummyPassword(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = utils.HashPassword("code-racer-dummy-password")
+	})
+	utils.CheckPasswordHash(password, dummyHash)
+}
+
+func accountLockKey(email string) string {
+	return "acct:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipLockKey(ip string) string {
+	return "ip:" + ip
+}
+
+// checkLocked returns a LockedError if the account or the IP is locked. Store
+// errors fail open: a Redis outage must not lock everyone out.
+func (us *AuthService) checkLocked(ctx context.Context, email, ip string) error {
+	var retryAfter time.Duration
+	for _, key := range []string{accountLockKey(email), ipLockKey(ip)} {
+		locked, err := us.loginAttempts.LockedFor(ctx, key)
+		if err != nil {
+			us.logger.Warnw("failed to read sign-in lockout", "key", key, "error", err)
+			continue
+		}
+		if locked > retryAfter {
+			retryAfter = locked
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// loginFailed records a failed sign-in for the account and the IP, locks
+// whichever crossed its limit and slows down repeated failures.
+func (us *AuthService) loginFailed(ctx context.Context, email, ip string) error {
+	accountKey := accountLockKey(email)
+	accountFailures, err := us.loginAttempts.RecordFailure(ctx, accountKey, loginFailureWindow)
+	if err != nil {
+		us.logger.Warnw("failed to record sign-in failure", "error", err)
+	}
+	ipFailures, err := us.loginAttempts.RecordFailure(ctx, ipLockKey(ip), loginFailureWindow)
+	if err != nil {
+		us.logger.Warnw("failed to record sign-in failure", "error", err)
+	}
+
+	var locked *LockedError
+	if accountFailures >= maxAccountFailures {
+		lockouts, err := us.loginAttempts.RecordFailure(ctx, "lockouts:"+accountKey, lockoutHistoryTTL)
+		if err != nil {
+			lockouts = 1
+		}
+		duration := accountLockout << (lockouts - 1)
+		if duration > maxAccountLockout || duration <= 0 {
+			duration = maxAccountLockout
+		}
+
+		us.lock(ctx, accountKey, duration)
+		us.logger.Warnw("sign-in lockout",
+			"event", audit.ActionLockout, "scope", "account", "email", email, "ip", ip,
+			"failures", accountFailures, "lockouts", lockouts, "duration", duration.String())
+		us.recordLockout("account", email, ip, accountFailures, duration)
+		locked = &LockedError{RetryAfter: duration}
+	}
+
+	if ipFailures >= maxIPFailures {
+		us.lock(ctx, ipLockKey(ip), ipLockout)
+		us.logger.Warnw("sign-in lockout",
+			"event", audit.ActionLockout, "scope", "ip", "ip", ip, "failures", ipFailures, "duration", ipLockout.String())
+		us.recordLockout("ip", email, ip, ipFailures, ipLockout)
+		if locked == nil || locked.RetryAfter < ipLockout {
+			locked = &LockedError{RetryAfter: ipLockout}
+		}
+	}
+
+	if locked != nil {
+		return locked
+	}
+
+	failureDelay(ctx, accountFailures)
+	return ErrInvalidCredentials
+}
+
+// recordLockout writes the lockout to the audit log, where admins can list
+// them by the auth.lockout action.
+func (us *AuthService) recordLockout(scope, email, ip string, failures int64, duration time.Duration) {
+	if us.audit == nil {
+		return
+	}
+	us.audit.Record(audit.Event{Action: audit.ActionLockout, Success: true, IP: ip, At: time.Now()}.
+		With("scope", scope).
+		With("email", email).
+		With("failures", failures).
+		With("duration", duration.String()))
+}
+
+// checkReauthPassword checks the password of a signed-in user who confirms a
+// sensitive change. Wrong passwords count toward the sign-in lockout.
+func (us *AuthService) checkReauthPassword(ctx context.Context, user *models.User, password, ip string) error {
+	if err := us.checkLocked(ctx, user.Email, ip); err != nil {
+		return err
+	}
+	if !utils.CheckPasswordHash(password, user.Password) {
+		if err := us.loginFailed(ctx, user.Email, ip); errors.Is(err, ErrAccountLocked) {
+			return err
+		}
+		return ErrInvalidCurrentPassword
+	}
+	return nil
+}
+
+func (us *AuthService) lock(ctx context.Context, key string, duration time.Duration) {
+	if err := us.loginAttempts.Lock(ctx, key, duration); err != nil {
+		us.logger.Errorw("failed to lock sign-in", "key", key, "error", err)
+		return
+	}
+	// 잠금 해제 후에는 실패 횟수를 처음부터 다시 셈
+	if err := us.loginAttempts.Reset(ctx, key); err != nil {
+		us.logger.Warnw("failed to reset sign-in failures", "key", key, "error", err)
+	}
+}
+
+func (us *AuthService) loginSucceeded(ctx context.Context, email string) {
+	if err := us.loginAttempts.Reset(ctx, accountLockKey(email)); err != nil {
+		us.logger.Warnw("failed to reset sign-in failures", "error", err)
+	}
+}
+
+// failureDelay waits 0.5s, 1s, 2s, ... (at most 5s) once an account has more
+// than delayAfterFailures consecutive failures.
+func failureDelay(ctx context.Context, failures int64) {
+	if failures < delayAfterFailures {
+		return
+	}
+
+	delay := baseFailureDelay << (failures - delayAfterFailures)
+	if delay > maxFailureDelay || delay <= 0 {
+		delay = maxFailureDelay
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/audit"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeUserRepository 는 테스트에서 쓰는 메서드만 구현 (나머지는 호출 시 panic)
+type fakeUserRepository struct {
+	repositories.UserRepositoryInterface
+	users      map[uint]*models.User
+	identities map[string]*models.UserIdentity // provider + ":" + subject
+}
+
+func (r *fakeUserRepository) GetByID(_ context.Context, id int) (*models.User, error) {
+	user, ok := r.users[uint(id)]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// fakeTwoFactorRepository 는 확인된 TOTP 하나를 가지고 모든 코드를 거부
+type fakeTwoFactorRepository struct {
+	repositories.TwoFactorRepositoryInterface
+	totp *models.UserTOTP
+}
+
+func (r *fakeTwoFactorRepository) GetTOTP(context.Context, uint) (*models.UserTOTP, error) {
+	if r.totp == nil {
+		return nil, repositories.ErrNotFound
+	}
+	return r.totp, nil
+}
+
+func (r *fakeTwoFactorRepository) UseTOTPStep(context.Context, uint, int64) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepository) UseRecoveryCode(context.Context, uint, string) (bool, error) {
+	return false, nil
+}
+
+type fakeRecorder struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (r *fakeRecorder) Record(e audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+const (
+	lockoutTestEmail    = "racer@example.com"
+	lockoutTestPassword = "racecar42"
+)
+
+func newLockoutTestService(t *testing.T) (*AuthService, *fakeRecorder) {
+	t.Helper()
+	// 비교 비용은 해시에 기록된 cost 를 따르므로 테스트에서는 최소 cost 로 생성
+	hash, err := bcrypt.GenerateFromPassword([]byte(lockoutTestPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirmed := time.Now()
+	recorder := &fakeRecorder{}
+	return &AuthService{
+		userRepository: &fakeUserRepository{users: map[uint]*models.User{
+			1: {ID: 1, Username: "racer", Email: lockoutTestEmail, Password: string(hash)},
+		}},
+		twoFactorRepository: &fakeTwoFactorRepository{totp: &models.UserTOTP{
+			UserID: 1, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", ConfirmedAt: &confirmed,
+		}},
+		sessionRepository: newFakeSessionRepository(),
+		loginAttempts:     cache.NewLoginAttemptMemoryImpl(),
+		audit:             recorder,
+		logger:            zap.NewNop().Sugar(),
+	}, recorder
+}
+
+// 재인증 경로의 실패도 로그인 실패와 같은 잠금 횟수에 포함되고, 잠금은 감사 로그에 남음
+func TestReauthFailuresLockTheAccount(t *testing.T) {
+	const ip = "203.0.113.7"
+
+	tests := []struct {
+		name      string
+		attempt   func(ctx context.Context, us *AuthService) error
+		wantWrong error
+	}{
+		{
+			name: "change password",
+			attempt: func(ctx context.Context, us *AuthService) error {
+				return us.ChangePassword(ctx, 1, "wrong-password1", "newpassword42", ip)
+			},
+			wantWrong: ErrInvalidCurrentPassword,
+		},
+		{
+			name: "disable 2fa password",
+			attempt: func(ctx context.Context, us *AuthService) error {
+				return us.DisableTOTP(ctx, 1, "wrong-password1", "000000", ip)
+			},
+			wantWrong: ErrInvalidCurrentPassword,
+		},
+		{
+			name: "disable 2fa code",
+			attempt: func(ctx context.Context, us *AuthService) error {
+				return us.DisableTOTP(ctx, 1, lockoutTestPassword, "not-a-code", ip)
+			},
+			wantWrong: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "regenerate recovery codes",
+			attempt: func(ctx context.Context, us *AuthService) error {
+				_, err := us.RegenerateRecoveryCodes(ctx, 1, "not-a-code", ip)
+				return err
+			},
+			wantWrong: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "schedule deletion",
+			attempt: func(ctx context.Context, us *AuthService) error {
+				_, err := us.ScheduleAccountDeletion(ctx, 1, "wrong-password1", "", ip)
+				return err
+			},
+			wantWrong: ErrInvalidCurrentPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us, recorder := newLockoutTestService(t)
+			// 취소된 context 로 실패 응답 지연(failureDelay)을 건너뜀
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			for i := 1; i < maxAccountFailures; i++ {
+				if err := tt.attempt(ctx, us); !errors.Is(err, tt.wantWrong) {
+					t.Fatalf("attempt %d: error = %v, want %v", i, err, tt.wantWrong)
+				}
+			}
+
+			var locked *LockedError
+			if err := tt.attempt(ctx, us); !errors.As(err, &locked) || locked.RetryAfter != accountLockout {
+				t.Fatalf("attempt %d: error = %v, want a %s lockout", maxAccountFailures, err, accountLockout)
+			}
+			// 잠긴 동안은 비밀번호를 확인하지 않음
+			if err := tt.attempt(ctx, us); !errors.As(err, &locked) {
+				t.Errorf("attempt while locked: error = %v, want a lockout", err)
+			}
+
+			if len(recorder.events) != 1 {
+				t.Fatalf("recorded %d audit events, want 1", len(recorder.events))
+			}
+			e := recorder.events[0]
+			if e.Action != audit.ActionLockout || e.IP != ip || e.Metadata["email"] != lockoutTestEmail ||
+				e.Metadata["scope"] != "account" || e.Metadata["duration"] != accountLockout.String() {
+				t.Errorf("audit event = %+v", e)
+			}
+		})
+	}
+}
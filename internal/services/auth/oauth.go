@@ -0,0 +1,368 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	ProviderGitHub = "github"
+	ProviderGoogle = "google"
+
+	// 로그인 시작부터 콜백까지 허용 시간
+	OAuthStateTTL = 10 * time.Minute
+)
+
+var (
+	ErrUnknownProvider     = errors.New("unknown oauth provider")
+	ErrInvalidOAuthState   = errors.New("invalid or expired oauth state")
+	ErrOAuthExchangeFailed = errors.New("oauth code exchange failed")
+	ErrOAuthEmailMissing   = errors.New("the provider did not share a verified email address")
+)
+
+// OAuthProvider is an OAuth2 provider users can sign in with. UserInfoURL is
+// the provider's profile endpoint (GitHub: /user, Google: OpenID userinfo).
+type OAuthProvider struct {
+	Name        string
+	Config      *oauth2.Config
+	UserInfoURL string
+}
+
+// NewOAuthProvider builds a provider. Endpoints are passed in rather than
+// hard-coded so a local fake server can stand in for the real provider.
+func NewOAuthProvider(name, clientID, clientSecret, authURL, tokenURL, userInfoURL, redirectURL string, scopes []string) (OAuthProvider, error) {
+	if name != ProviderGitHub && name != ProviderGoogle {
+		return OAuthProvider{}, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+
+	return OAuthProvider{
+		Name: name,
+		Config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: tokenURL,
+			},
+			RedirectURL: redirectURL,
+			Scopes:      scopes,
+		},
+		UserInfoURL: userInfoURL,
+	}, nil
+}
+
+// externalProfile is the part of a provider profile we use.
+type externalProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+}
+
+// BeginOAuth returns the provider URL to send the user to, and the state the
+// callback must come back with. The PKCE verifier never leaves the server.
+func (us *AuthService) BeginOAuth(ctx context.Context, providerName string) (string, string, error) {
+	provider, ok := us.oauthProviders[providerName]
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+
+	state, stateHash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	value := provider.Name + ":" + verifier
+	if err := us.ticketStore.Set(ctx, cache.TicketOAuthState, stateHash, value, OAuthStateTTL); err != nil {
+		return "", "", fmt.Errorf("failed to store oauth state: %w", err)
+	}
+
+	return provider.Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), state, nil
+}
+
+// CompleteOAuth handles the provider callback: it validates the state,
+// exchanges the code and returns the linked user, creating or linking the
+// account on first login. The caller starts the session (or asks for the
+// second factor).
+func (us *AuthService) CompleteOAuth(ctx context.Context, providerName, state, code string) (*mapper.MappedUser, error) {
+	provider, ok := us.oauthProviders[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	value, found, err := us.ticketStore.Consume(ctx, cache.TicketOAuthState, utils.HashToken(state))
+	if err != nil {
+		return nil, err
+	}
+	stateProvider, verifier, _ := strings.Cut(value, ":")
+	if !found || stateProvider != provider.Name || verifier == "" {
+		return nil, ErrInvalidOAuthState
+	}
+
+	token, err := provider.Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		us.logger.Warnw("oauth code exchange failed", "provider", provider.Name, "error", err)
+		return nil, ErrOAuthExchangeFailed
+	}
+
+	profile, err := us.fetchProfile(ctx, provider, provider.Config.Client(ctx, token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s profile: %w", provider.Name, err)
+	}
+
+	user, err := us.resolveOAuthUser(ctx, provider.Name, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.UserMapper(user), nil
+}
+
+// OAuthCompleteURL is the web client page the callback redirects to once the
+// sign-in finished, with an optional query parameter such as "error" or
+// "challenge".
+func (us *AuthService) OAuthCompleteURL(key, value string) string {
+	target := strings.TrimRight(us.appURL, "/") + "/oauth/complete"
+	if key != "" {
+		target += "?" + url.Values{key: {value}}.Encode()
+	}
+	return target
+}
+
+// resolveOAuthUser finds the user for an external identity. An unknown
+// identity is linked to the account with the same email only if the provider
+// verified that email; otherwise a new account is created.
+func (us *AuthService) resolveOAuthUser(ctx context.Context, providerName string, profile *externalProfile) (*models.User, error) {
+	identity, err := us.userRepository.GetIdentity(ctx, providerName, profile.Subject)
+	if err == nil {
+		return us.userRepository.GetByID(ctx, int(identity.UserID))
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		return nil, ErrOAuthEmailMissing
+	}
+
+	identity = &models.UserIdentity{
+		Provider: providerName,
+		Subject:  profile.Subject,
+		Email:    profile.Email,
+	}
+
+	if existing, err := us.userRepository.GetByEmail(ctx, profile.Email); err == nil {
+		// 인증되지 않은 계정은 메일 주인이 아닌 사람이 미리 만들어 둔 것일 수 있으므로
+		// 연결 전에 비밀번호를 알 수 없는 값으로 바꾸고 기존 세션을 모두 끊음
+		if !existing.IsActive {
+			if err := us.scrubUnverifiedUser(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+		identity.UserID = existing.ID
+		if err := us.userRepository.CreateIdentity(ctx, identity); err != nil {
+			return nil, fmt.Errorf("failed to link identity: %w", err)
+		}
+		// 제공자가 이메일을 확인했으므로 인증된 것으로 처리
+		if !existing.IsActive {
+			if err := us.userRepository.Activate(ctx, existing.Email); err != nil {
+				return nil, err
+			}
+			existing.IsActive = true
+		}
+		us.logger.Infow("linked oauth identity to existing user", "provider", providerName, "userID", existing.ID)
+		return existing, nil
+	}
+
+	return us.createOAuthUser(ctx, profile, identity)
+}
+
+func (us *AuthService) createOAuthUser(ctx context.Context, profile *externalProfile, identity *models.UserIdentity) (*models.User, error) {
+	role, err := us.roleRepository.GetByName(ctx, models.RoleUser)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := us.availableUsername(ctx, profile.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	// 외부 로그인 전용 계정은 알 수 없는 비밀번호로 생성 (재설정으로 설정 가능)
+	hashedPassword, err := unknownPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := us.userRepository.CreateWithIdentity(ctx, &models.User{
+		Username: username,
+		Password: hashedPassword,
+		Email:    profile.Email,
+		IsActive: true,
+		RoleID:   role.ID,
+	}, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	user.Role = role
+
+	us.logger.Infow("created user from oauth sign-in", "provider", identity.Provider, "userID", user.ID)
+	return user, nil
+}
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// availableUsername derives a free username from the provider's login.
+func (us *AuthService) availableUsername(ctx context.Context, base string) (string, error) {
+	base = usernameInvalidChars.ReplaceAllString(base, "")
+	if len(base) < 3 {
+		base = "racer"
+	}
+	if len(base) > 24 {
+		base = base[:24]
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		exists, err := us.userRepository.UsernameExists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists && !utils.IsReservedUsername(candidate) {
+			return candidate, nil
+		}
+		candidate = base + strconv.Itoa(1000+rand.Intn(9000))
+	}
+
+	return "", fmt.Errorf("could not find a free username for %q", base)
+}
+
+func (us *AuthService) fetchProfile(ctx context.Context, provider OAuthProvider, client *http.Client) (*externalProfile, error) {
+	switch provider.Name {
+	case ProviderGitHub:
+		return fetchGitHubProfile(ctx, client, provider.UserInfoURL)
+	case ProviderGoogle:
+		return fetchGoogleProfile(ctx, client, provider.UserInfoURL)
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+func fetchGitHubProfile(ctx context.Context, client *http.Client, userURL string) (*externalProfile, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err := getJSON(ctx, client, userURL, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("github profile has no id")
+	}
+
+	// /user의 email은 공개 이메일이며 인증 여부를 알 수 없으므로 /user/emails 사용
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, client, strings.TrimRight(userURL, "/")+"/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &externalProfile{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Username: user.Login,
+	}
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			profile.Email = e.Email
+			profile.EmailVerified = true
+			break
+		}
+	}
+
+	return profile, nil
+}
+
+func fetchGoogleProfile(ctx context.Context, client *http.Client, userInfoURL string) (*externalProfile, error) {
+	var info struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := getJSON(ctx, client, userInfoURL, &info); err != nil {
+		return nil, err
+	}
+	if info.Subject == "" {
+		return nil, fmt.Errorf("google profile has no subject")
+	}
+
+	localPart, _, _ := strings.Cut(info.Email, "@")
+	return &externalProfile{
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Username:      localPart,
+	}, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// scrubUnverifiedUser drops whatever credentials were set on an account whose
+// email was never verified, so only the verified owner can use it afterwards.
+func (us *AuthService) scrubUnverifiedUser(ctx context.Context, user *models.User) error {
+	hashedPassword, err := unknownPasswordHash()
+	if err != nil {
+		return err
+	}
+	if err := us.userRepository.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	return us.RevokeUserSessions(ctx, user.ID)
+}
+
+// unknownPasswordHash hashes a random password nobody knows.
+func unknownPasswordHash() (string, error) {
+	randomPassword, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return utils.HashPassword(randomPassword)
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"go.uber.org/zap"
+)
+
+func (r *fakeUserRepository) GetIdentity(_ context.Context, provider, subject string) (*models.UserIdentity, error) {
+	identity, ok := r.identities[provider+":"+subject]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	return identity, nil
+}
+
+// fakeGitHub 는 PKCE 를 검사하는 토큰 엔드포인트와 프로필 API 를 흉내냄
+type fakeGitHub struct {
+	mu        sync.Mutex
+	challenge string // 마지막으로 보낸 인가 요청의 code_challenge
+}
+
+func (f *fakeGitHub) setChallenge(t *testing.T, authURL string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Fatalf("auth URL %s has no S256 code challenge", authURL)
+	}
+	f.mu.Lock()
+	f.challenge = q.Get("code_challenge")
+	f.mu.Unlock()
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		f.mu.Lock()
+		ok := base64.RawURLEncoding.EncodeToString(sum[:]) == f.challenge && r.PostForm.Get("code") == "good-code"
+		f.mu.Unlock()
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"gh-token","token_type":"bearer"}`))
+	})
+	profile := func(body interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer gh-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(body)
+		}
+	}
+	mux.HandleFunc("/user", profile(map[string]interface{}{"id": 42, "login": "racer"}))
+	mux.HandleFunc("/user/emails", profile([]map[string]interface{}{
+		{"email": lockoutTestEmail, "primary": true, "verified": true},
+	}))
+	return mux
+}
+
+func newOAuthTestService(t *testing.T) (*AuthService, *fakeGitHub) {
+	t.Helper()
+	gh := &fakeGitHub{}
+	server := httptest.NewServer(gh.handler(t))
+	t.Cleanup(server.Close)
+
+	providers := make(map[string]OAuthProvider)
+	for _, name := range []string{ProviderGitHub, ProviderGoogle} {
+		provider, err := NewOAuthProvider(name, "client-id", "client-secret",
+			server.URL+"/authorize", server.URL+"/token", server.URL+"/user",
+			"http://api.example/callback", []string{"read:user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		providers[name] = provider
+	}
+
+	return &AuthService{
+		userRepository: &fakeUserRepository{
+			users: map[uint]*models.User{1: {ID: 1, Username: "racer", Email: lockoutTestEmail, IsActive: true}},
+			identities: map[string]*models.UserIdentity{
+				ProviderGitHub + ":42": {UserID: 1, Provider: ProviderGitHub, Subject: "42"},
+			},
+		},
+		ticketStore:    cache.NewTicketMemoryImpl(),
+		oauthProviders: providers,
+		logger:         zap.NewNop().Sugar(),
+	}, gh
+}
+
+func TestCompleteOAuth(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// 로그인을 시작한 뒤 콜백으로 돌아온 provider, state, code 를 고름
+		callback func(t *testing.T, us *AuthService, gh *fakeGitHub, state string) (string, string, string)
+		wantErr  error
+	}{
+		{
+			name: "valid state and verifier",
+			callback: func(_ *testing.T, _ *AuthService, _ *fakeGitHub, state string) (string, string, string) {
+				return ProviderGitHub, state, "good-code"
+			},
+		},
+		{
+			name: "unknown state",
+			callback: func(_ *testing.T, _ *AuthService, _ *fakeGitHub, _ string) (string, string, string) {
+				return ProviderGitHub, "forged-state", "good-code"
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "replayed state",
+			callback: func(t *testing.T, us *AuthService, _ *fakeGitHub, state string) (string, string, string) {
+				if _, err := us.CompleteOAuth(ctx, ProviderGitHub, state, "good-code"); err != nil {
+					t.Fatal(err)
+				}
+				return ProviderGitHub, state, "good-code"
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "state of another provider",
+			callback: func(_ *testing.T, _ *AuthService, _ *fakeGitHub, state string) (string, string, string) {
+				return ProviderGoogle, state, "good-code"
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "unknown provider",
+			callback: func(_ *testing.T, _ *AuthService, _ *fakeGitHub, state string) (string, string, string) {
+				return "gitlab", state, "good-code"
+			},
+			wantErr: ErrUnknownProvider,
+		},
+		{
+			// 다른 로그인 시도의 code 에는 그 시도의 verifier 가 필요
+			name: "verifier of another sign-in",
+			callback: func(t *testing.T, us *AuthService, gh *fakeGitHub, state string) (string, string, string) {
+				authURL, _, err := us.BeginOAuth(ctx, ProviderGitHub)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gh.setChallenge(t, authURL)
+				return ProviderGitHub, state, "good-code"
+			},
+			wantErr: ErrOAuthExchangeFailed,
+		},
+		{
+			name: "rejected code",
+			callback: func(_ *testing.T, _ *AuthService, _ *fakeGitHub, state string) (string, string, string) {
+				return ProviderGitHub, state, "bad-code"
+			},
+			wantErr: ErrOAuthExchangeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us, gh := newOAuthTestService(t)
+			authURL, state, err := us.BeginOAuth(ctx, ProviderGitHub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gh.setChallenge(t, authURL)
+			if u, _ := url.Parse(authURL); u.Query().Get("state") != state {
+				t.Fatalf("auth URL %s does not carry the state", authURL)
+			}
+
+			provider, callbackState, code := tt.callback(t, us, gh, state)
+			user, err := us.CompleteOAuth(ctx, provider, callbackState, code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompleteOAuth() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && user.ID != 1 {
+				t.Errorf("CompleteOAuth() user = %d, want the linked user 1", user.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+)
+
+const (
+	PasswordResetTTL = time.Hour
+	// 같은 주소로 재설정 메일을 다시 보낼 수 있는 간격
+	passwordResetInterval = time.Minute
+)
+
+var (
+	ErrInvalidResetToken      = errors.New("invalid or expired password reset link")
+	ErrPasswordResetThrottled = errors.New("password reset email was sent recently, please wait before retrying")
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+)
+
+// ForgotPassword mails a single-use reset link. Links mailed earlier stop
+// working, as do all links once the password changes. Like
+// ResendVerification it does not reveal whether the address belongs to an
+// account.
+func (us *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+
+	result, err := us.rateLimits.Take(ctx, "password-forgot:"+strings.ToLower(email), 1, passwordResetInterval)
+	if err != nil {
+		return err
+	}
+	if !result.Allowed {
+		return ErrPasswordResetThrottled
+	}
+
+	user, err := us.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	err = us.userRepository.CreatePasswordResetToken(ctx, &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(PasswordResetTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(us.appURL, "/"), url.QueryEscape(token))
+	body := fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to reset the password of your Code Racer account. Choose a new password here:\n\n%s\n\nThe link expires in %d minutes and can only be used once. If you did not ask for this, ignore this email.\n",
+		user.Username, link, int(PasswordResetTTL.Minutes()),
+	)
+
+	return us.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your Code Racer password",
+		Body:    body,
+	})
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword and signs
+// the user out everywhere.
+func (us *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	resetToken, err := us.userRepository.GetPasswordResetTokenByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := us.userRepository.GetByID(ctx, int(resetToken.UserID))
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	if err := utils.ValidatePassword(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	ok, err := us.userRepository.ResetPassword(ctx, resetToken.ID, user.ID, hashed)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidResetToken
+	}
+
+	// 메일로 받은 링크를 사용했으므로 이메일 소유가 확인됨
+	if !user.IsActive {
+		if err := us.userRepository.Activate(ctx, user.Email); err != nil {
+			us.logger.Warnw("failed to activate user after password reset", "userID", user.ID, "error", err)
+		}
+	}
+
+	return us.RevokeUserSessions(ctx, user.ID)
+}
+
+// ChangePassword replaces the password of a signed-in user and revokes all of
+// the user's sessions, including the current one. A wrong current password
+// counts toward the sign-in lockout like a failed sign-in.
+func (us *AuthService) ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword, ip string) error {
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return err
+	}
+	if err := us.checkReauthPassword(ctx, user, currentPassword, ip); err != nil {
+		return err
+	}
+
+	if err := utils.ValidatePassword(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := us.userRepository.UpdatePassword(ctx, user.ID, hashed); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return us.RevokeUserSessions(ctx, user.ID)
+}
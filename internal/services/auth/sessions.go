@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+type TokenPair struct {
+	SessionID        string    `json:"-"`
+	AccessToken      string    `json:"token"`
+	AccessExpiresAt  time.Time `json:"token_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// CreateSession signs the user in on a new device and issues the first
+// access/refresh token pair of the session. Signing in cancels a pending
+// account deletion.
+func (us *AuthService) CreateSession(ctx context.Context, user *mapper.MappedUser, userAgent, ip string) (*TokenPair, error) {
+	if user.DeletionScheduledAt != nil {
+		if err := us.cancelAccountDeletion(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := us.sessionRepository.CreateSession(ctx, &models.AuthSession{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		UserAgent: userAgent,
+		IP:        ip,
+		ExpiresAt: time.Now().Add(utils.SessionMaxLifetime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	us.cacheSessionState(ctx, session.ID, true, utils.AccessTokenTTL)
+
+	return us.issueTokens(ctx, session)
+}
+
+// Refresh rotates a refresh token. A refresh token can only be used once;
+// presenting one that was already rotated means it was stolen, so the whole
+// session (token family) is revoked.
+func (us *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	token, err := us.sessionRepository.GetRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	session, err := us.sessionRepository.GetSession(ctx, token.SessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) || now.After(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	fresh, err := us.sessionRepository.UseRefreshToken(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		us.logger.Warnw("refresh token reuse detected, revoking session", "sessionID", session.ID, "userID", session.UserID)
+		if err := us.RevokeSession(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return us.issueTokens(ctx, session)
+}
+
+func (us *AuthService) RevokeSession(ctx context.Context, sessionID string) error {
+	if err := us.sessionRepository.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	us.cacheSessionState(ctx, sessionID, false, utils.AccessTokenTTL)
+	return nil
+}
+
+// RevokeUserSessions signs the user out everywhere.
+func (us *AuthService) RevokeUserSessions(ctx context.Context, userID uint) error {
+	ids, err := us.sessionRepository.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	for _, id := range ids {
+		us.cacheSessionState(ctx, id, false, utils.AccessTokenTTL)
+	}
+	return nil
+}
+
+func (us *AuthService) issueTokens(ctx context.Context, session *models.AuthSession) (*TokenPair, error) {
+	accessToken, accessExpiresAt, err := utils.GenerateAccessToken(fmt.Sprint(session.UserID), session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, refreshHash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	refreshExpiresAt := time.Now().Add(utils.RefreshTokenTTL)
+	if refreshExpiresAt.After(session.ExpiresAt) {
+		refreshExpiresAt = session.ExpiresAt
+	}
+
+	err = us.sessionRepository.CreateRefreshToken(ctx, &models.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: refreshHash,
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// cacheSessionState caches the session state for at most an access token
+// lifetime, so a failed cache write cannot keep a revoked session alive for
+// longer than that.
+func (us *AuthService) cacheSessionState(ctx context.Context, sessionID string, active bool, ttl time.Duration) {
+	if us.sessionStore == nil {
+		return
+	}
+	if err := us.sessionStore.Set(ctx, sessionID, active, ttl); err != nil {
+		us.logger.Warnw("failed to cache session state", "sessionID", sessionID, "error", err)
+	}
+}
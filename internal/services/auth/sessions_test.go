@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"go.uber.org/zap"
+)
+
+// fakeSessionRepository 는 세션과 refresh token 을 메모리에 보관
+type fakeSessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*models.AuthSession
+	tokens   map[uint]*models.RefreshToken
+	nextID   uint
+}
+
+func newFakeSessionRepository() *fakeSessionRepository {
+	return &fakeSessionRepository{
+		sessions: make(map[string]*models.AuthSession),
+		tokens:   make(map[uint]*models.RefreshToken),
+	}
+}
+
+func (r *fakeSessionRepository) CreateSession(_ context.Context, s *models.AuthSession) (*models.AuthSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *s
+	r.sessions[s.ID] = &copied
+	return s, nil
+}
+
+func (r *fakeSessionRepository) GetSession(_ context.Context, id string) (*models.AuthSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *s
+	return &copied, nil
+}
+
+func (r *fakeSessionRepository) RevokeSession(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[id]; ok && s.RevokedAt == nil {
+		now := time.Now()
+		s.RevokedAt = &now
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeUserSessions(_ context.Context, userID uint) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	now := time.Now()
+	for id, s := range r.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt = &now
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeSessionRepository) CreateRefreshToken(_ context.Context, t *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	t.ID = r.nextID
+	copied := *t
+	r.tokens[t.ID] = &copied
+	return nil
+}
+
+func (r *fakeSessionRepository) GetRefreshTokenByHash(_ context.Context, hash string) (*models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.tokens {
+		if t.TokenHash == hash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (r *fakeSessionRepository) UseRefreshToken(_ context.Context, id uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return true, nil
+}
+
+// fakeSessionStore 는 마지막으로 캐시된 세션 상태를 기록
+type fakeSessionStore struct {
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+func (s *fakeSessionStore) Get(_ context.Context, id string) (bool, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	active, found := s.active[id]
+	return active, found, nil
+}
+
+func (s *fakeSessionStore) Set(_ context.Context, id string, active bool, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active[id] = active
+	return nil
+}
+
+func newSessionTestService(t *testing.T) (*AuthService, *fakeSessionRepository, *fakeSessionStore) {
+	t.Helper()
+	utils.SetSecret("test-secret")
+	repo := newFakeSessionRepository()
+	store := &fakeSessionStore{active: make(map[string]bool)}
+	return &AuthService{
+		sessionRepository: repo,
+		sessionStore:      store,
+		logger:            zap.NewNop().Sugar(),
+	}, repo, store
+}
+
+func TestRefreshRotation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// 세션을 만든 뒤 Refresh 에 넘길 토큰을 고름
+		token       func(t *testing.T, svc *AuthService, repo *fakeSessionRepository, first *TokenPair) string
+		wantErr     error
+		wantRevoked bool
+	}{
+		{
+			name: "fresh token rotates",
+			token: func(_ *testing.T, _ *AuthService, _ *fakeSessionRepository, first *TokenPair) string {
+				return first.RefreshToken
+			},
+		},
+		{
+			name: "rotated token is reuse",
+			token: func(t *testing.T, svc *AuthService, _ *fakeSessionRepository, first *TokenPair) string {
+				if _, err := svc.Refresh(ctx, first.RefreshToken); err != nil {
+					t.Fatal(err)
+				}
+				return first.RefreshToken
+			},
+			wantErr:     ErrRefreshTokenReused,
+			wantRevoked: true,
+		},
+		{
+			name: "unknown token",
+			token: func(_ *testing.T, _ *AuthService, _ *fakeSessionRepository, _ *TokenPair) string {
+				return "not-a-token"
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired token",
+			token: func(_ *testing.T, _ *AuthService, repo *fakeSessionRepository, first *TokenPair) string {
+				for _, tok := range repo.tokens {
+					tok.ExpiresAt = time.Now().Add(-time.Minute)
+				}
+				return first.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "revoked session",
+			token: func(t *testing.T, svc *AuthService, _ *fakeSessionRepository, first *TokenPair) string {
+				if err := svc.RevokeSession(ctx, first.SessionID); err != nil {
+					t.Fatal(err)
+				}
+				return first.RefreshToken
+			},
+			wantErr:     ErrInvalidRefreshToken,
+			wantRevoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, store := newSessionTestService(t)
+			first, err := svc.CreateSession(ctx, &mapper.MappedUser{ID: 7}, "test-agent", "127.0.0.1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pair, err := svc.Refresh(ctx, tt.token(t, svc, repo, first))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Refresh() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if pair.SessionID != first.SessionID || pair.RefreshToken == first.RefreshToken {
+					t.Errorf("Refresh() = %+v, want a new token for session %s", pair, first.SessionID)
+				}
+			}
+
+			session, _ := repo.GetSession(ctx, first.SessionID)
+			if revoked := session.RevokedAt != nil; revoked != tt.wantRevoked {
+				t.Errorf("session revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+			if active, _, _ := store.Get(ctx, first.SessionID); active == tt.wantRevoked {
+				t.Errorf("cached session active = %v, want %v", active, !tt.wantRevoked)
+			}
+		})
+	}
+}
+
+// 재사용이 감지되면 새로 발급된 토큰까지 포함해 세션 전체가 폐기됨
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newSessionTestService(t)
+
+	first, err := svc.CreateSession(ctx, &mapper.MappedUser{ID: 7}, "test-agent", "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := svc.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("reusing the first token: error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := svc.Refresh(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("refreshing after reuse: error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
@@ -0,0 +1,286 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+)
+
+const (
+	TOTPIssuer = "Code Racer"
+	// 비밀번호 확인 후 두 번째 인증까지 허용 시간
+	TwoFactorChallengeTTL = 5 * time.Minute
+	// 챌린지 하나로 시도할 수 있는 코드 입력 횟수
+	twoFactorMaxAttempts = 5
+	recoveryCodeCount    = 10
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("start the two-factor enrollment first")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidChallenge        = errors.New("invalid or expired sign-in challenge")
+)
+
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorChallenge is returned by the first sign-in step when 2FA is
+// enabled. It is exchanged for a session together with a valid code.
+type TwoFactorChallenge struct {
+	Token     string    `json:"challenge"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (us *AuthService) TwoFactorEnabled(ctx context.Context, userID uint) (bool, error) {
+	totp, err := us.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return totp.Enabled(), nil
+}
+
+// BeginTOTPEnrollment generates a new secret. 2FA stays disabled until the
+// user proves the authenticator works with ConfirmTOTPEnrollment.
+func (us *AuthService) BeginTOTPEnrollment(ctx context.Context, user *mapper.MappedUser) (*TOTPEnrollment, error) {
+	enabled, err := us.TwoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := us.twoFactorRepository.SavePendingTOTP(ctx, user.ID, secret); err != nil {
+		return nil, fmt.Errorf("failed to save totp secret: %w", err)
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTPEnrollment enables 2FA and returns the recovery codes. They are
+// shown once; only their hashes are stored.
+func (us *AuthService) ConfirmTOTPEnrollment(ctx context.Context, userID uint, code string) ([]string, error) {
+	totp, err := us.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if totp.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := utils.ValidateTOTP(totp.Secret, code, time.Now(), 0)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := us.twoFactorRepository.ConfirmTOTP(ctx, userID, step, hashes); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	us.logger.Infow("two-factor authentication enabled", "userID", userID)
+	return codes, nil
+}
+
+// DisableTOTP turns 2FA off. It asks for the password and a current code (or
+// recovery code) so a stolen session alone cannot remove the second factor.
+func (us *AuthService) DisableTOTP(ctx context.Context, userID uint, password, code, ip string) error {
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return err
+	}
+	if err := us.checkReauthPassword(ctx, user, password, ip); err != nil {
+		return err
+	}
+
+	if err := us.verifySecondFactor(ctx, userID, user.Email, code, ip); err != nil {
+		return err
+	}
+
+	if err := us.twoFactorRepository.DisableTOTP(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	us.logger.Infow("two-factor authentication disabled", "userID", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, used or not.
+func (us *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code, ip string) ([]string, error) {
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return nil, err
+	}
+	if err := us.verifySecondFactor(ctx, userID, user.Email, code, ip); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := us.twoFactorRepository.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// CreateTwoFactorChallenge is the result of a correct password for a user with
+// 2FA enabled. No session exists until the challenge is verified.
+func (us *AuthService) CreateTwoFactorChallenge(ctx context.Context, userID uint) (*TwoFactorChallenge, error) {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.storeChallenge(ctx, hash, userID, 0); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorChallenge{Token: token, ExpiresAt: time.Now().Add(TwoFactorChallengeTTL)}, nil
+}
+
+// VerifyTwoFactorChallenge completes the sign-in with a TOTP or recovery code.
+// A challenge survives a few wrong codes so a typo does not restart sign-in;
+// wrong codes count toward the same lockout as wrong passwords.
+func (us *AuthService) VerifyTwoFactorChallenge(ctx context.Context, challenge, code, ip string) (*mapper.MappedUser, error) {
+	hash := utils.HashToken(challenge)
+	value, found, err := us.ticketStore.Consume(ctx, cache.TicketTwoFactor, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrInvalidChallenge
+	}
+
+	userIDStr, attemptsStr, _ := strings.Cut(value, ":")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	attempts, _ := strconv.Atoi(attemptsStr)
+
+	user, err := us.GetUserByID(ctx, int(userID))
+	if err != nil {
+		return nil, err
+	}
+	if err := us.verifySecondFactor(ctx, uint(userID), user.Email, code, ip); err != nil {
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			return nil, err
+		}
+		if attempts+1 < twoFactorMaxAttempts {
+			if err := us.storeChallenge(ctx, hash, uint(userID), attempts+1); err != nil {
+				us.logger.Warnw("failed to keep two-factor challenge", "userID", userID, "error", err)
+			}
+		}
+		return nil, err
+	}
+	us.loginSucceeded(ctx, user.Email)
+
+	return user, nil
+}
+
+func (us *AuthService) storeChallenge(ctx context.Context, hash string, userID uint, attempts int) error {
+	value := fmt.Sprintf("%d:%d", userID, attempts)
+	if err := us.ticketStore.Set(ctx, cache.TicketTwoFactor, hash, value, TwoFactorChallengeTTL); err != nil {
+		return fmt.Errorf("failed to store two-factor challenge: %w", err)
+	}
+	return nil
+}
+
+// verifySecondFactor checks a TOTP or recovery code like checkSecondFactor.
+// Wrong codes count toward the same lockout as wrong passwords.
+func (us *AuthService) verifySecondFactor(ctx context.Context, userID uint, email, code, ip string) error {
+	if err := us.checkLocked(ctx, email, ip); err != nil {
+		return err
+	}
+
+	err := us.checkSecondFactor(ctx, userID, code)
+	if errors.Is(err, ErrInvalidTwoFactorCode) {
+		if err := us.loginFailed(ctx, email, ip); errors.Is(err, ErrAccountLocked) {
+			return err
+		}
+	}
+	return err
+}
+
+// checkSecondFactor accepts a current TOTP code that was not used before, or
+// an unused recovery code.
+func (us *AuthService) checkSecondFactor(ctx context.Context, userID uint, code string) error {
+	totp, err := us.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrTwoFactorNotEnabled
+		}
+		return err
+	}
+	if !totp.Enabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := utils.ValidateTOTP(totp.Secret, code, time.Now(), totp.LastUsedStep); ok {
+		fresh, err := us.twoFactorRepository.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := us.twoFactorRepository.UseRecoveryCode(ctx, userID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if remaining, err := us.twoFactorRepository.CountUnusedRecoveryCodes(ctx, userID); err == nil && remaining <= 2 {
+		us.logger.Infow("user is running out of recovery codes", "userID", userID, "remaining", remaining)
+	}
+	return nil
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashToken(utils.NormalizeRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
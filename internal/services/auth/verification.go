@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mailer"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+)
+
+// 같은 주소로 인증 메일을 다시 보낼 수 있는 간격
+const verificationResendInterval = time.Minute
+
+var (
+	ErrEmailNotVerified         = errors.New("email address is not verified")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification link")
+	ErrVerificationThrottled    = errors.New("verification email was sent recently, please wait before retrying")
+)
+
+// SendVerificationEmail mails the user a signed link to GET /users/verify.
+func (us *AuthService) SendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := utils.GenerateActionToken(
+		strconv.FormatUint(uint64(user.ID), 10),
+		user.Email,
+		utils.PurposeEmailVerification,
+		utils.EmailVerificationTTL,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to sign verification token: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/api/v1/users/verify?token=%s", strings.TrimRight(us.publicURL, "/"), url.QueryEscape(token))
+	body := fmt.Sprintf(
+		"Hi %s,\n\nConfirm your email address to start racing:\n\n%s\n\nThe link expires in %d hours. If you did not sign up, ignore this email.\n",
+		user.Username, link, int(utils.EmailVerificationTTL.Hours()),
+	)
+
+	return us.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your Code Racer account",
+		Body:    body,
+	})
+}
+
+// VerifyEmail activates the account a verification link was issued for.
+// Verifying an already active account succeeds.
+func (us *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := utils.ParseActionToken(token, utils.PurposeEmailVerification)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := us.userRepository.GetByEmail(ctx, claims.Email)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+	// 링크 발급 후 이메일이 다른 계정으로 넘어간 경우 방지
+	if strconv.FormatUint(uint64(user.ID), 10) != claims.UserID {
+		return ErrInvalidVerificationToken
+	}
+	if user.IsActive {
+		return nil
+	}
+
+	return us.userRepository.Activate(ctx, user.Email)
+}
+
+// ResendVerification sends a new verification link. It does not reveal
+// whether the address belongs to an account: unknown and already verified
+// addresses are silently ignored.
+func (us *AuthService) ResendVerification(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+
+	result, err := us.rateLimits.Take(ctx, "verify-resend:"+strings.ToLower(email), 1, verificationResendInterval)
+	if err != nil {
+		return err
+	}
+	if !result.Allowed {
+		return ErrVerificationThrottled
+	}
+
+	user, err := us.userRepository.GetByEmail(ctx, email)
+	if err != nil || user.IsActive {
+		return nil
+	}
+
+	return us.SendVerificationEmail(ctx, user)
+}
@@ -8,17 +8,25 @@ package game
 import (
 	"encoding/json"
 	"log"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/markdown"
 )
 
 // Game represents the game state.
 type Game struct {
 	// TODO: implement game structure here
-	editors []Editor
+	editors    []Editor
+	Problem    *models.Problem `json:"-"`
+	StartedAt  time.Time       `json:"started_at,omitempty"`
+	FinishedAt time.Time       `json:"finished_at,omitempty"`
+	WinnerID   uint            `json:"winner_id,omitempty"`
 }
 
 type Editor struct {
-	id   string `json:"id"`
-	code string `json:"code"`
+	id   string
+	code string
 }
 
 type GameMessageType string
@@ -52,3 +60,41 @@ func createRoomMessage(room *Room, player *Player) []byte {
 
 	return msgBytes
 }
+
+// problemPayload is the part of a problem that players are allowed to see,
+// in the first of the player's locales the problem is written in.
+func problemPayload(problem *models.Problem, locales []string) map[string]interface{} {
+	samples := make([]map[string]string, 0)
+	for _, tc := range problem.Samples() {
+		samples = append(samples, map[string]string{
+			"input":          tc.Input,
+			"expectedOutput": tc.ExpectedOutput,
+		})
+	}
+
+	locale, title, statement := problem.Localized(locales)
+	statementHTML, err := markdown.Render(statement)
+	if err != nil {
+		log.Printf("Error rendering statement of problem %d: %v", problem.ID, err)
+	}
+
+	return map[string]interface{}{
+		"id":            problem.ID,
+		"title":         title,
+		"statement":     statement,
+		"statementHtml": statementHTML,
+		"locale":        locale,
+		"timeLimit":     problem.TimeLimit,
+		"memoryLimit":   problem.MemoryLimit,
+		"samples":       samples,
+	}
+}
+
+func createMessage(msgType string, payload interface{}) []byte {
+	msgBytes, err := json.Marshal(Message{Type: msgType, Payload: payload})
+	if err != nil {
+		log.Printf("Error marshaling %s message: %v", msgType, err)
+		return nil
+	}
+	return msgBytes
+}
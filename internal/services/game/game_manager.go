@@ -1,37 +1,69 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
 	"github.com/google/uuid"
 )
 
+// Limits bound how much a single API instance hosts.
+type Limits struct {
+	MaxRooms          int           // 동시에 열 수 있는 방
+	MaxPlayersPerRoom int           // 방 하나의 최대 인원
+	SubmissionTimeout time.Duration // 제출 하나를 채점하는 데 허용되는 최대 시간
+}
+
+var DefaultLimits = Limits{
+	MaxRooms:          1000,
+	MaxPlayersPerRoom: 8,
+	SubmissionTimeout: 2 * time.Minute,
+}
+
 // GameManager manages game rooms and game logic.
 type GameManager struct {
 	Rooms      map[string]*Room `json:"rooms"`
 	Mutex      sync.Mutex       `json:"-"`
 	Register   chan *Player     `json:"-"`
 	Unregister chan *Player     `json:"-"`
-	IsRunning  bool             `json:"-"`
+
+	running atomic.Bool
+
+	problems repositories.ProblemRepositoryInterface
+	matches  repositories.MatchRepositoryInterface
+	judge    judge.JudgeService
+	limits   Limits
 }
 
 // NewGameManager creates a new GameManager.
-func NewGameManager() *GameManager {
+func NewGameManager(problems repositories.ProblemRepositoryInterface, matches repositories.MatchRepositoryInterface, judgeService judge.JudgeService, limits Limits) *GameManager {
 	return &GameManager{
 		Rooms:      make(map[string]*Room),
 		Register:   make(chan *Player),
 		Unregister: make(chan *Player),
+		problems:   problems,
+		matches:    matches,
+		judge:      judgeService,
+		limits:     limits,
 	}
 }
 
 // Run starts the GameManager loop.
 func (gm *GameManager) Run() {
-	gm.IsRunning = true
-	defer func() {
-		gm.IsRunning = false
-	}()
+	gm.running.Store(true)
+	defer gm.running.Store(false)
 	fmt.Println("Game Manager started")
 	for {
 		select {
@@ -44,9 +76,16 @@ func (gm *GameManager) Run() {
 	}
 }
 
+// IsRunning reports whether the Run loop is accepting players.
+func (gm *GameManager) IsRunning() bool {
+	return gm.running.Load()
+}
+
 // handlePlayerJoin handles a new player joining the game.
 func (gm *GameManager) handlePlayerJoin(player *Player) {
 	player.send = make(chan []byte, 256)
+	player.done = make(chan struct{})
+	metrics.WebSocketConnections.Inc()
 
 	msg, _ := json.Marshal(Message{Type: "init", Payload: map[string]string{"message": "hello"}})
 	player.send <- msg
@@ -68,19 +107,24 @@ func (gm *GameManager) handlePlayerLeave(player *Player) {
 			}
 		}
 
-		if len(player.Room.Players) == 0 {
+		empty := len(player.Room.Players) == 0
+		if empty {
 			player.Room.Status = "closed"
 			// gm.Rooms 수정 전 gm.Mutex를 잡음
 			gm.Mutex.Lock()
 			delete(gm.Rooms, player.Room.ID)
 			gm.Mutex.Unlock()
-		} else {
-			player.Room.Broadcast <- []byte(fmt.Sprintf("Player %d left", player.ID))
 		}
 		player.Room.Mutex.Unlock()
 
+		// room.run 이 룸 락을 잡으므로 Broadcast 송신은 락 밖에서
+		if !empty {
+			player.Room.Broadcast <- []byte(fmt.Sprintf("Player %d left", player.ID))
+		}
 		gm.broadcastRoomsList()
 	}
+	// writePump 를 끝냄; send 는 닫지 않음
+	player.drop()
 }
 
 // CreateRoom creates a new game room.
@@ -90,6 +134,14 @@ func (gm *GameManager) CreateRoom(player *Player) *Room {
 	//
 	//
 
+	gm.Mutex.Lock()
+	full := len(gm.Rooms) >= gm.limits.MaxRooms
+	gm.Mutex.Unlock()
+	if full {
+		player.send <- createErrorMessage("Too many rooms are open, please try again later")
+		return nil
+	}
+
 	// 룸 생성에 필요한 데이터 준비
 	roomID := uuid.NewString()
 	room := &Room{
@@ -123,27 +175,42 @@ func (gm *GameManager) CreateRoom(player *Player) *Room {
 // JoinRoom allows a player to join a specific room.
 func (gm *GameManager) JoinRoom(player *Player, roomID string) {
 	gm.Mutex.Lock()
-	defer gm.Mutex.Unlock()
-
 	room, ok := gm.Rooms[roomID]
+	gm.Mutex.Unlock()
 	if !ok {
 		// Handle room not found
 		player.send <- createErrorMessage("Room not found")
 		return
 	}
 
+	if _, banned := room.banned.Load(player.ID); banned {
+		player.send <- createErrorMessage("You are banned from this room")
+		return
+	}
+
+	// 룸 상태와 인원은 룸의 락으로 확인 및 변경, 메시지 송신은 락 밖에서 진행
+	room.Mutex.Lock()
 	if room.Status != "waiting" {
+		room.Mutex.Unlock()
 		player.send <- createErrorMessage("Room is not available")
 		return
 	}
 
+	if len(room.Players) >= gm.limits.MaxPlayersPerRoom {
+		room.Mutex.Unlock()
+		player.send <- createErrorMessage("Room is full")
+		return
+	}
+
 	room.Players[player.ID] = player
 	player.Room = room
 	player.IsHost = false
 	player.IsReady = false
+	joined := createRoomMessage(room, player)
+	room.Mutex.Unlock()
 
 	// Notify player about joining the room
-	player.send <- createRoomMessage(room, player)
+	player.send <- joined
 
 	// Notify other players about the new player
 	room.Broadcast <- []byte(fmt.Sprintf("Player %d joined", player.ID))
@@ -152,6 +219,87 @@ func (gm *GameManager) JoinRoom(player *Player, roomID string) {
 	gm.broadcastRoomsList()
 }
 
+// StartGame picks a problem for the room and starts the race.
+func (gm *GameManager) StartGame(room *Room) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	problem, err := gm.problems.GetRandomPublished(ctx)
+	if err != nil {
+		log.Printf("failed to pick a problem for room %s: %v", room.ID, err)
+		room.Broadcast <- createErrorMessage("No problem available")
+		return
+	}
+
+	room.StartGame(problem)
+}
+
+// SubmitCode judges a player's code against the room's problem. Judging runs
+// in the background so the player's read loop is not blocked while Judge0
+// executes the test cases.
+func (gm *GameManager) SubmitCode(ctx context.Context, player *Player, languageID int, code string) {
+	room := player.Room
+	if room == nil {
+		player.trySend(createErrorMessage("You are not in a room"))
+		return
+	}
+
+	room.Mutex.Lock()
+	if room.Status != "playing" || room.Game.Problem == nil {
+		room.Mutex.Unlock()
+		player.trySend(createErrorMessage("Game is not in progress"))
+		return
+	}
+	if player.submitting {
+		room.Mutex.Unlock()
+		player.trySend(createErrorMessage("Previous submission is still being judged"))
+		return
+	}
+	player.submitting = true
+	player.languageID = languageID
+	problem := room.Game.Problem
+	room.Mutex.Unlock()
+
+	go func() {
+		// 채점은 메시지 처리보다 오래 걸리므로 span 만 이어받음
+		ctx, cancel := context.WithTimeout(tracing.Detach(ctx), gm.limits.SubmissionTimeout)
+		defer cancel()
+
+		verdict, err := gm.judge.Judge(ctx, problem, languageID, code)
+
+		room.Mutex.Lock()
+		player.submitting = false
+		room.Mutex.Unlock()
+
+		if err != nil {
+			if errors.Is(err, languages.ErrUnsupportedLanguage) {
+				player.trySend(createErrorMessage("Unsupported language"))
+				return
+			}
+			log.Printf("failed to judge submission of player %d in room %s: %v", player.ID, room.ID, err)
+			player.trySend(createErrorMessage("Failed to judge submission"))
+			return
+		}
+
+		if match := room.handleVerdict(player, verdict); match != nil {
+			gm.recordMatch(match)
+		}
+	}()
+}
+
+// recordMatch stores a finished match and updates the players' ratings.
+func (gm *GameManager) recordMatch(match *models.Match) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := gm.matches.RecordMatch(ctx, match, func(ratings map[uint]int) map[uint]int {
+		return UpdateRatings(ratings, *match.WinnerID)
+	})
+	if err != nil {
+		log.Printf("failed to record match of room %s: %v", match.RoomID, err)
+	}
+}
+
 func createErrorMessage(message string) []byte {
 	msg := Message{
 		Type:    "error",
@@ -161,13 +309,24 @@ func createErrorMessage(message string) []byte {
 	return msgBytes
 }
 
-// getRoomsList returns a list of all available rooms.
-func (gm *GameManager) getRoomsList() []map[string]interface{} {
+// rooms returns a snapshot of the rooms held by the manager. Callers lock each
+// room on its own afterwards, keeping the room lock before gm.Mutex order.
+func (gm *GameManager) rooms() []*Room {
 	gm.Mutex.Lock()
 	defer gm.Mutex.Unlock()
 
-	roomsList := make([]map[string]interface{}, 0)
+	rooms := make([]*Room, 0, len(gm.Rooms))
 	for _, room := range gm.Rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// getRoomsList returns a list of all available rooms.
+func (gm *GameManager) getRoomsList() []map[string]interface{} {
+	roomsList := make([]map[string]interface{}, 0)
+	for _, room := range gm.rooms() {
+		room.Mutex.Lock()
 		if room.Status == "waiting" {
 			roomData := map[string]interface{}{
 				"id":      room.ID,
@@ -176,18 +335,27 @@ func (gm *GameManager) getRoomsList() []map[string]interface{} {
 			}
 			roomsList = append(roomsList, roomData)
 		}
+		room.Mutex.Unlock()
 	}
 	return roomsList
 }
 
 // broadcastRoomsList sends the updated rooms list to all connected players.
+// It must be called without holding gm.Mutex or any room lock.
 func (gm *GameManager) broadcastRoomsList() {
-	roomsList := gm.getRoomsList()
-	msg, _ := json.Marshal(Message{Type: "rooms_list", Payload: roomsList})
+	rooms := gm.rooms()
+	msg, _ := json.Marshal(Message{Type: "rooms_list", Payload: gm.getRoomsList()})
 
-	for _, room := range gm.Rooms {
+	var players []*Player
+	for _, room := range rooms {
+		room.Mutex.Lock()
 		for _, player := range room.Players {
-			player.send <- msg
+			players = append(players, player)
 		}
+		room.Mutex.Unlock()
+	}
+
+	for _, player := range players {
+		player.trySend(msg)
 	}
 }
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+)
+
+func newTestPlayer(id uint) *Player {
+	return &Player{ID: id, send: make(chan []byte, 16), done: make(chan struct{})}
+}
+
+// JoinRoom 이 gm.Mutex 를 잡은 채 방 목록을 브로드캐스트하면 교착 상태가 됨
+func TestJoinRoomDoesNotDeadlock(t *testing.T) {
+	gm := NewGameManager(nil, nil, judge.JudgeService{}, DefaultLimits)
+	host := newTestPlayer(1)
+	room := gm.CreateRoom(host)
+	if room == nil {
+		t.Fatal("CreateRoom returned nil")
+	}
+
+	guest := newTestPlayer(2)
+	done := make(chan struct{})
+	go func() {
+		gm.JoinRoom(guest, room.ID)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("JoinRoom did not return")
+	}
+
+	room.Mutex.Lock()
+	_, joined := room.Players[guest.ID]
+	room.Mutex.Unlock()
+	if !joined || guest.Room != room {
+		t.Fatal("guest was not added to the room")
+	}
+
+	rooms := gm.getRoomsList()
+	if len(rooms) != 1 || rooms[0]["players"] != 2 {
+		t.Errorf("getRoomsList() = %v, want one room with 2 players", rooms)
+	}
+}
+
+func TestJoinRoomRejects(t *testing.T) {
+	gm := NewGameManager(nil, nil, judge.JudgeService{}, Limits{MaxRooms: 10, MaxPlayersPerRoom: 2})
+	full := gm.CreateRoom(newTestPlayer(1))
+	gm.JoinRoom(newTestPlayer(2), full.ID)
+	open := gm.CreateRoom(newTestPlayer(4))
+
+	tests := []struct {
+		name   string
+		roomID string
+		setup  func()
+	}{
+		{"unknown room", "missing", func() {}},
+		{"full room", full.ID, func() {}},
+		{"banned player", open.ID, func() { open.banned.Store(uint(3), struct{}{}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			player := newTestPlayer(3)
+			gm.JoinRoom(player, tt.roomID)
+			if player.Room != nil {
+				t.Fatal("player joined a room it should not have")
+			}
+			select {
+			case msg := <-player.send:
+				var m Message
+				if json.Unmarshal(msg, &m) != nil || m.Type != "error" {
+					t.Errorf("got %s, want an error message", msg)
+				}
+			default:
+				t.Error("player was not told why the join failed")
+			}
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
 	"log"
+	"sync"
 	"time"
 
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Player represents a connected player.
@@ -17,6 +23,18 @@ type Player struct {
 	IsReady bool            `json:"isReady"`
 	send    chan []byte     `json:"-"`
 	Code    string          `json:"code"`
+
+	// send 는 닫지 않음: 느리거나 나간 플레이어는 done 을 닫아 writePump 만 끝내고,
+	// 룸에서의 정리는 Unregister 가 맡음 (채점 고루틴이 나중에 보내도 panic 없음)
+	done     chan struct{}
+	dropOnce sync.Once
+
+	submitting bool     // 채점 중인 제출이 있는지, Room.Mutex 로 보호
+	languageID int      // 마지막으로 제출한 언어, Room.Mutex 로 보호
+	locales    []string // 문제 설명 언어, 선호 순
+
+	// 웹소켓을 연 HTTP 요청의 span; 메시지마다 만드는 span 이 이를 링크함
+	connSpan trace.SpanContext
 }
 
 // readPump handles messages from the client.
@@ -24,6 +42,7 @@ func (p *Player) readPump(manager *GameManager) {
 	defer func() {
 		manager.Unregister <- p
 		p.Conn.Close()
+		metrics.WebSocketConnections.Dec()
 	}()
 
 	for {
@@ -49,12 +68,10 @@ func (p *Player) writePump() {
 
 	for {
 		select {
-		case message, ok := <-p.send:
-			if !ok {
-				// Channel closed, exit loop
-				p.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
+		case <-p.done:
+			p.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			return
+		case message := <-p.send:
 			w, err := p.Conn.NextWriter(websocket.TextMessage)
 			if err != nil {
 				return // Error writing, exit loop
@@ -63,6 +80,7 @@ func (p *Player) writePump() {
 			if err := w.Close(); err != nil {
 				return // Error closing writer, exit loop
 			}
+			metrics.WebSocketMessages.WithLabelValues("out", messageType(message)).Inc()
 		}
 	}
 }
@@ -75,6 +93,19 @@ func (p *Player) handleMessage(message []byte, manager *GameManager) {
 		log.Printf("error unmarshalling message: %v", err)
 		return
 	}
+	msgType := metricsType(msg.Type)
+	metrics.WebSocketMessages.WithLabelValues("in", msgType).Inc()
+
+	// 연결이 몇 시간씩 유지되므로 메시지마다 새 trace 를 시작하고 연결 요청에 링크
+	ctx, span := tracing.Tracer().Start(context.Background(), "ws."+msgType,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithLinks(trace.Link{SpanContext: p.connSpan}),
+		trace.WithAttributes(attribute.Int("user.id", int(p.ID))),
+	)
+	defer span.End()
+	if p.Room != nil {
+		span.SetAttributes(attribute.String("room.id", p.Room.ID))
+	}
 
 	switch msg.Type {
 	case MessageTypeCreateRoom:
@@ -92,23 +123,75 @@ func (p *Player) handleMessage(message []byte, manager *GameManager) {
 		}
 		manager.JoinRoom(p, roomID)
 	case MessageTypePlayerReady:
+		room := p.Room
+		if room == nil {
+			p.IsReady = true
+			return
+		}
+		// Players 는 SubmitCode, RemovePlayer 등 다른 고루틴도 바꾸므로 룸 락 안에서 확인
+		room.Mutex.Lock()
 		p.IsReady = true
-		// Check if all players in the room are ready and start the game
-		if p.Room != nil {
-			allReady := true
-			for _, player := range p.Room.Players {
-				if !player.IsReady {
-					allReady = false
-					break
-				}
-			}
-			if allReady {
-				p.Room.StartGame()
+		allReady := true
+		for _, player := range room.Players {
+			if !player.IsReady {
+				allReady = false
+				break
 			}
 		}
+		room.Mutex.Unlock()
+		// Check if all players in the room are ready and start the game
+		if allReady {
+			manager.StartGame(room)
+		}
 	case MessageTypeStart:
 		if p.Room != nil && p.IsHost {
-			p.Room.StartGame()
+			manager.StartGame(p.Room)
 		}
+	case MessageTypeSubmitCode:
+		payload, ok := msg.Payload.(map[string]interface{})
+		if !ok {
+			log.Println("Invalid payload for submitCode")
+			return
+		}
+		code, ok := payload["code"].(string)
+		if !ok {
+			log.Println("Invalid payload for submitCode: missing or invalid 'code'")
+			return
+		}
+		languageID, ok := payload["languageId"].(float64)
+		if !ok {
+			log.Println("Invalid payload for submitCode: missing or invalid 'languageId'")
+			return
+		}
+		manager.SubmitCode(ctx, p, int(languageID), code)
+	}
+}
+
+// drop disconnects a player that cannot keep up or has left. It is safe to
+// call more than once and from any goroutine.
+func (p *Player) drop() {
+	p.dropOnce.Do(func() {
+		if p.done != nil {
+			close(p.done)
+		}
+	})
+}
+
+// trySend queues a message without blocking; it reports false when the
+// player was dropped or the player's buffer is full and the message was
+// dropped.
+func (p *Player) trySend(msg []byte) bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+	}
+
+	select {
+	case p.send <- msg:
+		return true
+	default:
+		metrics.WebSocketDropped.WithLabelValues(messageType(msg)).Inc()
+		return false
 	}
 }
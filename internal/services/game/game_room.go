@@ -4,20 +4,29 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
 )
 
 type Room struct {
 	ID        string           `json:"id"`
 	Players   map[uint]*Player `json:"players"`
-	Status    string           `json:"status"` // "waiting", "playing", "closed"
+	Status    string           `json:"status"` // "waiting", "playing", "finished", "closed"
 	Game      *Game            `json:"game"`
 	Mutex     sync.Mutex       `json:"-"`
 	Broadcast chan []byte      `json:"-"`
+
+	banned sync.Map // 강퇴되어 다시 입장할 수 없는 사용자 ID
 }
 
 func (room *Room) run() {
 	defer func() {
+		room.Mutex.Lock()
 		room.Status = "closed"
+		room.Mutex.Unlock()
 	}()
 
 	for {
@@ -37,12 +46,12 @@ func (room *Room) run() {
 			case "codeUpdate":
 				room.handleCodeUpdate(msg)
 			default:
-				for _, player := range room.Players {
+				for _, player := range room.players() {
 					select {
 					case player.send <- msg: //player.send <- msg:
 					default:
-						close(player.send)
-						delete(room.Players, player.ID)
+						metrics.WebSocketDropped.WithLabelValues(metricsType(parsedMsg.Type)).Inc()
+						player.drop()
 					}
 				}
 			}
@@ -78,10 +87,11 @@ func (room *Room) handleCodeUpdate(msg []byte) {
 	}
 
 	// 코드 업데이트
-	player, ok := room.Players[userID]
-	if ok {
+	room.Mutex.Lock()
+	if player, ok := room.Players[userID]; ok {
 		player.Code = code
 	}
+	room.Mutex.Unlock()
 
 	// 브로드캐스트
 	room.broadcastCodeUpdate(userID, code)
@@ -98,19 +108,36 @@ func (room *Room) broadcastCodeUpdate(userID uint, code string) {
 
 	updateMsgBytes, _ := json.Marshal(updateMsg)
 
-	for _, player := range room.Players {
-		if player.ID == userID {
-			player.Code = code
-		}
+	room.Mutex.Lock()
+	if player, ok := room.Players[userID]; ok {
+		player.Code = code
+	}
+	room.Mutex.Unlock()
+
+	for _, player := range room.players() {
 		select {
 		case player.send <- updateMsgBytes:
 		default:
-			close(player.send)
-			delete(room.Players, player.ID)
+			metrics.WebSocketDropped.WithLabelValues("codeUpdate").Inc()
+			player.drop()
 		}
 	}
 }
 
+// players returns a snapshot of the players in the room, so messages can be
+// sent without holding room.Mutex. Dropped players stay in the map until
+// Unregister removes them.
+func (room *Room) players() []*Player {
+	room.Mutex.Lock()
+	defer room.Mutex.Unlock()
+
+	players := make([]*Player, 0, len(room.Players))
+	for _, player := range room.Players {
+		players = append(players, player)
+	}
+	return players
+}
+
 // StartGame starts the game in the room.
 func (room *Room) getHost() *Player {
 	for _, player := range room.Players {
@@ -121,9 +148,8 @@ func (room *Room) getHost() *Player {
 	return nil
 }
 
-func (room *Room) StartGame() {
+func (room *Room) StartGame(problem *models.Problem) {
 	room.Mutex.Lock()
-	defer room.Mutex.Unlock()
 
 	// Check if all players are ready
 	allReady := true
@@ -135,15 +161,92 @@ func (room *Room) StartGame() {
 	}
 
 	if !allReady {
+		room.Mutex.Unlock()
+		// room.run 이 룸 락을 잡으므로 Broadcast 송신은 락 밖에서
 		room.Broadcast <- createErrorMessage("Not all players are ready")
 		return
 	}
+	defer room.Mutex.Unlock()
 
 	room.Status = "playing"
-	// Notify all players that the game has started
-	msg := Message{Type: "gameStart"}
-	msgBytes, _ := json.Marshal(msg)
+	room.Game.Problem = problem
+	room.Game.StartedAt = time.Now()
+	metrics.MatchesStarted.Inc()
+
+	// Notify all players that the game has started, each in their own
+	// locale; players who get the same statement share a message.
+	messages := make(map[string][]byte)
 	for _, player := range room.Players {
-		player.send <- msgBytes
+		locale, _, _ := problem.Localized(player.locales)
+		msgBytes, ok := messages[locale]
+		if !ok {
+			msgBytes = createMessage(MessageTypeGameStart, map[string]interface{}{
+				"problem": problemPayload(problem, player.locales),
+			})
+			messages[locale] = msgBytes
+		}
+		player.trySend(msgBytes)
+	}
+}
+
+// handleVerdict reports a judged submission to its author, shares the
+// progress with the other players and finishes the game on the first
+// accepted submission. It returns the match to record when the game ended.
+func (room *Room) handleVerdict(player *Player, verdict *judge.Verdict) *models.Match {
+	room.Mutex.Lock()
+	defer room.Mutex.Unlock()
+
+	player.trySend(createMessage(MessageTypeSubmissionResult, verdict))
+
+	progress := createMessage(MessageTypePlayerProgress, map[string]interface{}{
+		"userID":      player.ID,
+		"status":      verdict.Status,
+		"passedTests": verdict.PassedTests,
+		"totalTests":  verdict.TotalTests,
+	})
+	for _, p := range room.Players {
+		if p.ID != player.ID {
+			p.trySend(progress)
+		}
+	}
+
+	if verdict.Status != judge.VerdictAccepted || room.Status != "playing" {
+		return nil
+	}
+
+	room.Status = "finished"
+	room.Game.WinnerID = player.ID
+	room.Game.FinishedAt = time.Now()
+	metrics.MatchesFinished.Inc()
+
+	gameOver := createMessage(MessageTypeGameOver, map[string]interface{}{
+		"winnerID":  player.ID,
+		"elapsedMs": room.Game.FinishedAt.Sub(room.Game.StartedAt).Milliseconds(),
+	})
+	for _, p := range room.Players {
+		p.trySend(gameOver)
+	}
+
+	return room.matchResult()
+}
+
+// matchResult snapshots the finished game. Must be called with room.Mutex held.
+func (room *Room) matchResult() *models.Match {
+	winnerID := room.Game.WinnerID
+	match := &models.Match{
+		RoomID:     room.ID,
+		ProblemID:  room.Game.Problem.ID,
+		WinnerID:   &winnerID,
+		Rated:      len(room.Players) > 1,
+		StartedAt:  room.Game.StartedAt,
+		FinishedAt: room.Game.FinishedAt,
+	}
+	for _, p := range room.Players {
+		match.Participants = append(match.Participants, models.MatchParticipant{
+			UserID:     p.ID,
+			LanguageID: p.languageID,
+			Won:        p.ID == winnerID,
+		})
 	}
+	return match
 }
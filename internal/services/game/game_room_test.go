@@ -0,0 +1,132 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+)
+
+func newTestRoom(players ...*Player) *Room {
+	room := &Room{
+		ID:        "room",
+		Players:   make(map[uint]*Player),
+		Status:    "playing",
+		Game:      &Game{},
+		Broadcast: make(chan []byte),
+	}
+	for _, p := range players {
+		room.Players[p.ID] = p
+		p.Room = room
+	}
+	return room
+}
+
+func isDropped(p *Player) bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTrySendAfterDrop(t *testing.T) {
+	p := newTestPlayer(1)
+	if !p.trySend([]byte("a")) {
+		t.Fatal("trySend failed on an open buffer")
+	}
+
+	p.drop()
+	p.drop() // 두 번 호출해도 안전
+	if p.trySend([]byte("b")) {
+		t.Error("trySend queued a message for a dropped player")
+	}
+
+	// 연결 전의 플레이어 (done 없음)
+	unjoined := &Player{ID: 2, send: make(chan []byte, 1)}
+	unjoined.drop()
+	if !unjoined.trySend([]byte("c")) {
+		t.Error("trySend failed for a player without done")
+	}
+}
+
+// 버퍼가 찬 플레이어는 send 를 닫지 않고 drop 만 하므로, 뒤늦은 채점 결과도 panic 없이 버려짐
+func TestSlowPlayerIsDroppedNotClosed(t *testing.T) {
+	slow := &Player{ID: 1, send: make(chan []byte), done: make(chan struct{})}
+	fast := newTestPlayer(2)
+	room := newTestRoom(slow, fast)
+
+	room.broadcastCodeUpdate(fast.ID, "print(1)")
+	if !isDropped(slow) {
+		t.Fatal("slow player was not dropped")
+	}
+	if isDropped(fast) {
+		t.Fatal("fast player was dropped")
+	}
+	if _, ok := room.Players[slow.ID]; !ok {
+		t.Error("dropped player was removed before Unregister")
+	}
+
+	room.handleVerdict(slow, &judge.Verdict{Status: judge.VerdictWrongAnswer, TotalTests: 1})
+	room.handleVerdict(fast, &judge.Verdict{Status: judge.VerdictWrongAnswer, TotalTests: 1})
+}
+
+func TestRoomRunDropsSlowPlayer(t *testing.T) {
+	slow := &Player{ID: 1, send: make(chan []byte), done: make(chan struct{})}
+	fast := newTestPlayer(2)
+	room := newTestRoom(slow, fast)
+	go room.run()
+
+	room.Broadcast <- createMessage(MessageTypePlayerRemoved, map[string]interface{}{"userID": 3})
+
+	select {
+	case <-slow.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow player was not dropped")
+	}
+	select {
+	case <-fast.send:
+	case <-time.After(2 * time.Second):
+		t.Fatal("fast player did not get the message")
+	}
+	close(room.Broadcast)
+}
+
+// go test -race 로 실행해야 의미가 있음
+func TestRoomBroadcastsWhilePlayersChange(t *testing.T) {
+	gm := NewGameManager(nil, nil, judge.JudgeService{}, DefaultLimits)
+	host := newTestPlayer(1)
+	room := gm.CreateRoom(host)
+	drain := func(p *Player) {
+		for {
+			select {
+			case <-p.send:
+			case <-p.done:
+				return
+			}
+		}
+	}
+	go drain(host)
+
+	var wg sync.WaitGroup
+	for i := uint(2); i < 8; i++ {
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			p := newTestPlayer(id)
+			go drain(p)
+			gm.JoinRoom(p, room.ID)
+			room.broadcastCodeUpdate(id, fmt.Sprintf("code %d", id))
+			room.handleVerdict(p, &judge.Verdict{Status: judge.VerdictWrongAnswer})
+			gm.handlePlayerLeave(p)
+		}(i)
+	}
+	wg.Wait()
+
+	if players := room.players(); len(players) != 1 || players[0] != host {
+		t.Errorf("room players = %v, want only the host", players)
+	}
+}
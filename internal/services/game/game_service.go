@@ -1,14 +1,21 @@
 package game
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// 웹소켓 티켓은 발급 직후 바로 사용되어야 함
+const WebSocketTicketTTL = 30 * time.Second
+
 var (
 	instance GameService
 	once     sync.Once
@@ -18,14 +25,16 @@ var (
 
 type GameService struct {
 	gameStore   cache.GameRedisStoreInterface
+	ticketStore cache.TicketStoreInterface
 	logger      *zap.SugaredLogger
 	gameManager *GameManager
 }
 
-func NewGameService(gameManager *GameManager, gameStore cache.GameRedisStoreInterface, logger *zap.SugaredLogger) GameService {
+func NewGameService(gameManager *GameManager, gameStore cache.GameRedisStoreInterface, ticketStore cache.TicketStoreInterface, logger *zap.SugaredLogger) GameService {
 	once.Do(func() {
 		instance = GameService{
 			gameStore:   gameStore,
+			ticketStore: ticketStore,
 			logger:      logger,
 			gameManager: gameManager,
 		}
@@ -33,7 +42,26 @@ func NewGameService(gameManager *GameManager, gameStore cache.GameRedisStoreInte
 	return instance
 }
 
-func (gs *GameService) ConnectGameSocketConnect(conn *websocket.Conn, userID uint) error {
+// IssueWebSocketTicket creates a single-use ticket that authenticates one
+// WebSocket handshake for the user's current session.
+func (gs *GameService) IssueWebSocketTicket(ctx context.Context, userID uint, sessionID string) (string, time.Time, error) {
+	ticket, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	value := fmt.Sprintf("%d:%s", userID, sessionID)
+	if err := gs.ticketStore.Set(ctx, cache.TicketWebSocket, hash, value, WebSocketTicketTTL); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to store websocket ticket: %w", err)
+	}
+
+	return ticket, time.Now().Add(WebSocketTicketTTL), nil
+}
+
+// ConnectGameSocketConnect registers the connection as a player. locales are
+// the player's preferred statement locales; ctx is the upgrade request's,
+// whose span the player's messages are linked to.
+func (gs *GameService) ConnectGameSocketConnect(ctx context.Context, conn *websocket.Conn, userID uint, locales []string) error {
 	if gs.gameManager == nil {
 		gs.logger.Errorf("ConnectGameSocketConnect(), gameManager is not created.")
 		return fmt.Errorf("gameManager is not created")
@@ -43,9 +71,11 @@ func (gs *GameService) ConnectGameSocketConnect(conn *websocket.Conn, userID uin
 		return fmt.Errorf("register channel is nil")
 	}
 	player := &Player{
-		ID:   userID,
-		Conn: conn,
-		send: make(chan []byte, 256),
+		ID:       userID,
+		Conn:     conn,
+		send:     make(chan []byte, 256),
+		locales:  locales,
+		connSpan: trace.SpanContextFromContext(ctx),
 	}
 	gs.logger.Debug("before Register")
 
@@ -65,3 +95,18 @@ func (gs *GameService) GetGameRooms() []map[string]interface{} {
 	gameRoom := gs.gameManager.getRoomsList()
 	return gameRoom
 }
+
+// RemovePlayer kicks (and optionally bans) a user from a room.
+func (gs *GameService) RemovePlayer(roomID string, userID uint, ban bool) error {
+	return gs.gameManager.RemovePlayer(roomID, userID, ban)
+}
+
+// ListRooms returns every live room, including rooms in play.
+func (gs *GameService) ListRooms() []RoomSummary {
+	return gs.gameManager.ListRooms()
+}
+
+// CloseRoom force-closes a room.
+func (gs *GameService) CloseRoom(roomID string) error {
+	return gs.gameManager.CloseRoom(roomID)
+}
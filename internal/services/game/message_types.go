@@ -1,8 +1,58 @@
 package game
 
+import "encoding/json"
+
 const (
 	MessageTypeCreateRoom  = "createRoom"
 	MessageTypeJoinRoom    = "joinRoom"
 	MessageTypePlayerReady = "playerReady"
 	MessageTypeStart       = "start"
+	MessageTypeSubmitCode  = "submitCode"
+
+	MessageTypeGameStart        = "gameStart"
+	MessageTypeSubmissionResult = "submissionResult"
+	MessageTypePlayerProgress   = "playerProgress"
+	MessageTypeGameOver         = "gameOver"
+	MessageTypeRemoved          = "removed"
+	MessageTypePlayerRemoved    = "playerRemoved"
+	MessageTypeRoomClosed       = "roomClosed"
 )
+
+// knownMessageTypes bounds the "type" label of the WebSocket metrics; a
+// client can send anything as its message type.
+var knownMessageTypes = map[string]bool{
+	MessageTypeCreateRoom:       true,
+	MessageTypeJoinRoom:         true,
+	MessageTypePlayerReady:      true,
+	MessageTypeStart:            true,
+	MessageTypeSubmitCode:       true,
+	MessageTypeGameStart:        true,
+	MessageTypeSubmissionResult: true,
+	MessageTypePlayerProgress:   true,
+	MessageTypeGameOver:         true,
+	MessageTypeRemoved:          true,
+	MessageTypePlayerRemoved:    true,
+	MessageTypeRoomClosed:       true,
+	"init":                      true,
+	"error":                     true,
+	"rooms_list":                true,
+	"codeUpdate":                true,
+}
+
+func metricsType(msgType string) string {
+	if knownMessageTypes[msgType] {
+		return msgType
+	}
+	return "other"
+}
+
+// messageType returns the metrics label of an encoded message.
+func messageType(msg []byte) string {
+	var m struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(msg, &m) != nil {
+		return "other"
+	}
+	return metricsType(m.Type)
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrRoomNotFound    = errors.New("room not found")
+	ErrPlayerNotInRoom = errors.New("player is not in the room")
+)
+
+// RemovePlayer takes a player out of a room on behalf of a moderator. With
+// ban the user also cannot join the room again; a user can be banned before
+// joining.
+func (gm *GameManager) RemovePlayer(roomID string, userID uint, ban bool) error {
+	gm.Mutex.Lock()
+	room, ok := gm.Rooms[roomID]
+	gm.Mutex.Unlock()
+	if !ok {
+		return ErrRoomNotFound
+	}
+
+	if ban {
+		room.banned.Store(userID, struct{}{})
+	}
+
+	room.Mutex.Lock()
+	player, inRoom := room.Players[userID]
+	if !inRoom {
+		room.Mutex.Unlock()
+		if ban {
+			return nil
+		}
+		return ErrPlayerNotInRoom
+	}
+
+	delete(room.Players, userID)
+	player.Room = nil
+	if player.IsHost {
+		player.IsHost = false
+		for _, p := range room.Players {
+			p.IsHost = true
+			break
+		}
+	}
+
+	empty := len(room.Players) == 0
+	if empty {
+		room.Status = "closed"
+		// 룸 락 다음에 gm.Mutex (handlePlayerLeave와 같은 순서)
+		gm.Mutex.Lock()
+		delete(gm.Rooms, room.ID)
+		gm.Mutex.Unlock()
+	}
+	room.Mutex.Unlock()
+
+	player.trySend(createMessage(MessageTypeRemoved, map[string]interface{}{
+		"roomID": roomID,
+		"banned": ban,
+	}))
+	if !empty {
+		room.Broadcast <- createMessage(MessageTypePlayerRemoved, map[string]interface{}{
+			"userID": userID,
+		})
+	}
+
+	gm.broadcastRoomsList()
+	return nil
+}
+
+// RoomsByStatus counts the rooms held by the manager by status.
+func (gm *GameManager) RoomsByStatus() map[string]int {
+	counts := map[string]int{"waiting": 0, "playing": 0, "finished": 0}
+	for _, room := range gm.rooms() {
+		room.Mutex.Lock()
+		counts[room.Status]++
+		room.Mutex.Unlock()
+	}
+	return counts
+}
+
+// RoomSummary describes a live room for operators.
+type RoomSummary struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	PlayerIDs []uint    `json:"player_ids"`
+	HostID    uint      `json:"host_id,omitempty"`
+	ProblemID uint      `json:"problem_id,omitempty"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+}
+
+// ListRooms returns every room held by the manager, whatever its status.
+func (gm *GameManager) ListRooms() []RoomSummary {
+	rooms := gm.rooms()
+	summaries := make([]RoomSummary, 0, len(rooms))
+	for _, room := range rooms {
+		room.Mutex.Lock()
+		summary := RoomSummary{
+			ID:        room.ID,
+			Status:    room.Status,
+			PlayerIDs: make([]uint, 0, len(room.Players)),
+		}
+		for id, p := range room.Players {
+			summary.PlayerIDs = append(summary.PlayerIDs, id)
+			if p.IsHost {
+				summary.HostID = id
+			}
+		}
+		if room.Game != nil && room.Game.Problem != nil {
+			summary.ProblemID = room.Game.Problem.ID
+			summary.StartedAt = room.Game.StartedAt
+		}
+		room.Mutex.Unlock()
+
+		sort.Slice(summary.PlayerIDs, func(i, j int) bool { return summary.PlayerIDs[i] < summary.PlayerIDs[j] })
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ID < summaries[j].ID })
+	return summaries
+}
+
+// CloseRoom ends a room on behalf of an operator. Every player is taken out
+// of the room and told why; a game in progress is not recorded as a match.
+func (gm *GameManager) CloseRoom(roomID string) error {
+	gm.Mutex.Lock()
+	room, ok := gm.Rooms[roomID]
+	gm.Mutex.Unlock()
+	if !ok {
+		return ErrRoomNotFound
+	}
+
+	room.Mutex.Lock()
+	players := make([]*Player, 0, len(room.Players))
+	for id, p := range room.Players {
+		players = append(players, p)
+		delete(room.Players, id)
+		p.Room = nil
+		p.IsHost = false
+	}
+	room.Status = "closed"
+	// 룸 락 다음에 gm.Mutex (handlePlayerLeave와 같은 순서)
+	gm.Mutex.Lock()
+	delete(gm.Rooms, room.ID)
+	gm.Mutex.Unlock()
+	room.Mutex.Unlock()
+
+	msg := createMessage(MessageTypeRoomClosed, map[string]interface{}{"roomID": roomID})
+	for _, p := range players {
+		p.trySend(msg)
+	}
+
+	gm.broadcastRoomsList()
+	return nil
+}
@@ -0,0 +1,34 @@
+package game
+
+import "math"
+
+// Elo K-factor. 여러 명이 참가하면 승자와 각 패자 간의 대결로 나누어 계산
+const eloK = 32.0
+
+// UpdateRatings applies the result of a race: the winner beat every other
+// participant. K is split across the pairings so a race against many players
+// moves ratings about as much as a duel.
+func UpdateRatings(ratings map[uint]int, winnerID uint) map[uint]int {
+	updated := make(map[uint]int, len(ratings))
+	for id, r := range ratings {
+		updated[id] = r
+	}
+
+	winnerRating, ok := ratings[winnerID]
+	if !ok || len(ratings) < 2 {
+		return updated
+	}
+
+	k := eloK / float64(len(ratings)-1)
+	for id, loserRating := range ratings {
+		if id == winnerID {
+			continue
+		}
+		expected := 1 / (1 + math.Pow(10, float64(loserRating-winnerRating)/400))
+		delta := int(math.Round(k * (1 - expected)))
+		updated[winnerID] += delta
+		updated[id] -= delta
+	}
+
+	return updated
+}
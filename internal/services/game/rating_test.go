@@ -0,0 +1,65 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpdateRatings(t *testing.T) {
+	tests := []struct {
+		name     string
+		ratings  map[uint]int
+		winnerID uint
+		want     map[uint]int
+	}{
+		{"equal duel", map[uint]int{1: 1500, 2: 1500}, 1, map[uint]int{1: 1516, 2: 1484}},
+		{"equal duel other winner", map[uint]int{1: 1500, 2: 1500}, 2, map[uint]int{1: 1484, 2: 1516}},
+		{"favourite wins", map[uint]int{1: 1600, 2: 1400}, 1, map[uint]int{1: 1608, 2: 1392}},
+		{"underdog wins", map[uint]int{1: 1600, 2: 1400}, 2, map[uint]int{1: 1576, 2: 1424}},
+
+		// 여러 명: K 를 나누어 각 패자와의 대결로 계산
+		{"three equal players", map[uint]int{1: 1500, 2: 1500, 3: 1500}, 1, map[uint]int{1: 1516, 2: 1492, 3: 1492}},
+		{"stronger loser loses more", map[uint]int{1: 1500, 2: 1700, 3: 1300}, 1, map[uint]int{1: 1516, 2: 1688, 3: 1296}},
+
+		// 승자가 없으면(무승부) 변화 없음
+		{"draw", map[uint]int{1: 1500, 2: 1600}, 0, map[uint]int{1: 1500, 2: 1600}},
+		{"single player", map[uint]int{1: 1500}, 1, map[uint]int{1: 1500}},
+		{"no players", map[uint]int{}, 1, map[uint]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := make(map[uint]int, len(tt.ratings))
+			for id, r := range tt.ratings {
+				before[id] = r
+			}
+
+			got := UpdateRatings(tt.ratings, tt.winnerID)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("UpdateRatings(%v, %d) = %v, want %v", tt.ratings, tt.winnerID, got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.ratings, before) {
+				t.Errorf("UpdateRatings modified its input: %v", tt.ratings)
+			}
+		})
+	}
+}
+
+// 승자가 얻은 점수와 패자들이 잃은 점수의 합은 같아야 함
+func TestUpdateRatingsZeroSum(t *testing.T) {
+	ratings := map[uint]int{1: 1234, 2: 1890, 3: 1500, 4: 987, 5: 2100}
+	for winnerID := range ratings {
+		var before, after int
+		updated := UpdateRatings(ratings, winnerID)
+		for id := range ratings {
+			before += ratings[id]
+			after += updated[id]
+		}
+		if before != after {
+			t.Errorf("winner %d: total rating %d -> %d", winnerID, before, after)
+		}
+		if updated[winnerID] <= ratings[winnerID] {
+			t.Errorf("winner %d did not gain rating: %d -> %d", winnerID, ratings[winnerID], updated[winnerID])
+		}
+	}
+}
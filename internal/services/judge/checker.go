@@ -0,0 +1,96 @@
+package judge
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+)
+
+const defaultFloatTolerance = 1e-6
+
+// Compare reports whether actual matches expected under the given mode. It
+// handles every mode except CompareCustom, which needs the executor.
+func Compare(mode models.CompareMode, expected, actual string, tolerance float64) bool {
+	switch mode {
+	case models.CompareWhitespace:
+		return equalTokens(strings.Fields(expected), strings.Fields(actual))
+	case models.CompareCaseInsensitive:
+		return strings.EqualFold(normalize(expected), normalize(actual))
+	case models.CompareFloat:
+		return equalFloats(strings.Fields(expected), strings.Fields(actual), tolerance)
+	case models.CompareUnorderedLines:
+		return equalTokens(sortedLines(expected), sortedLines(actual))
+	default:
+		return normalize(expected) == normalize(actual)
+	}
+}
+
+// normalize unifies line endings and drops trailing whitespace on each line
+// and trailing blank lines, which nobody expects to be significant.
+func normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func sortedLines(s string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(normalize(s), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func equalTokens(expected, actual []string) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalFloats compares numeric tokens with an absolute or relative tolerance
+// and every other token exactly.
+func equalFloats(expected, actual []string, tolerance float64) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	if tolerance <= 0 {
+		tolerance = defaultFloatTolerance
+	}
+
+	for i := range expected {
+		e, errE := strconv.ParseFloat(expected[i], 64)
+		a, errA := strconv.ParseFloat(actual[i], 64)
+		if errE != nil || errA != nil {
+			if expected[i] != actual[i] {
+				return false
+			}
+			continue
+		}
+		// NaN 과의 차이는 어떤 허용 오차와 비교해도 false 이므로 표기로 비교
+		if math.IsNaN(e) || math.IsInf(e, 0) || math.IsNaN(a) || math.IsInf(a, 0) {
+			if !strings.EqualFold(expected[i], actual[i]) {
+				return false
+			}
+			continue
+		}
+		diff := math.Abs(e - a)
+		if diff > tolerance && diff > tolerance*math.Abs(e) {
+			return false
+		}
+	}
+	return true
+}
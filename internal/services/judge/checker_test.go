@@ -0,0 +1,128 @@
+package judge
+
+import (
+	"testing"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge0"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      models.CompareMode
+		expected  string
+		actual    string
+		tolerance float64
+		want      bool
+	}{
+		// exact: 줄 끝 공백, CRLF, 마지막 빈 줄만 무시
+		{"exact equal", models.CompareExact, "1 2\n3\n", "1 2\n3\n", 0, true},
+		{"exact trailing spaces", models.CompareExact, "1 2\n3", "1 2  \n3\t\n", 0, true},
+		{"exact trailing blank lines", models.CompareExact, "42", "42\n\n\n", 0, true},
+		{"exact crlf", models.CompareExact, "a\nb", "a\r\nb\r\n", 0, true},
+		{"exact inner spaces differ", models.CompareExact, "1 2", "1  2", 0, false},
+		{"exact leading space", models.CompareExact, "1", " 1", 0, false},
+		{"exact missing line", models.CompareExact, "1\n2", "1", 0, false},
+		{"exact case differs", models.CompareExact, "Yes", "yes", 0, false},
+		{"empty mode is exact", "", "ok\n", "ok", 0, true},
+
+		// whitespace: 토큰 단위 비교
+		{"tokens across lines", models.CompareWhitespace, "1 2 3", "1\n2\n  3", 0, true},
+		{"tokens extra spaces", models.CompareWhitespace, "a b", "  a\t\tb  \n", 0, true},
+		{"tokens differ", models.CompareWhitespace, "1 2 3", "1 2 4", 0, false},
+		{"tokens extra token", models.CompareWhitespace, "1 2", "1 2 3", 0, false},
+		{"tokens order matters", models.CompareWhitespace, "1 2", "2 1", 0, false},
+
+		{"case insensitive", models.CompareCaseInsensitive, "YES\nNo", "yes\nno  \n", 0, true},
+		{"case insensitive differs", models.CompareCaseInsensitive, "yes", "yes!", 0, false},
+
+		{"unordered lines", models.CompareUnorderedLines, "a\nb\nc", "c\na\nb\n", 0, true},
+		{"unordered lines surrounding spaces", models.CompareUnorderedLines, "a\nb", "  b\na  \n\n", 0, true},
+		{"unordered lines duplicates count", models.CompareUnorderedLines, "a\na\nb", "a\nb\nb", 0, false},
+		{"unordered lines missing", models.CompareUnorderedLines, "a\nb", "a", 0, false},
+
+		// float: 기본 허용 오차 1e-6, 절대 또는 상대 오차 중 하나만 만족하면 통과
+		{"float exact", models.CompareFloat, "0.5", "0.5", 0, true},
+		{"float default tolerance", models.CompareFloat, "0.3333333", "0.33333333", 0, true},
+		{"float default tolerance exceeded", models.CompareFloat, "0.333", "0.334", 0, false},
+		{"float absolute", models.CompareFloat, "1.00", "1.009", 0.01, true},
+		{"float absolute exceeded", models.CompareFloat, "1.00", "1.02", 0.01, false},
+		{"float relative", models.CompareFloat, "1000000", "1005000", 0.01, true},
+		{"float relative exceeded", models.CompareFloat, "1000000", "1020000", 0.01, false},
+		{"float notation", models.CompareFloat, "1500", "1.5e3", 0, true},
+		{"float with words", models.CompareFloat, "area 3.14159", "area 3.1415901", 0, true},
+		{"float words differ", models.CompareFloat, "area 3.14", "Area 3.14", 0, false},
+		{"float more tokens", models.CompareFloat, "1 2", "1 2 3", 0, false},
+		{"float fewer tokens", models.CompareFloat, "1 2 3", "1 2", 0, false},
+		{"float nan output", models.CompareFloat, "1.5", "NaN", 1, false},
+		{"float nan expected", models.CompareFloat, "nan", "1.5", 1, false},
+		{"float nan both", models.CompareFloat, "nan", "NaN", 0, true},
+		{"float inf output", models.CompareFloat, "1e308", "+Inf", 1, false},
+		{"float inf both", models.CompareFloat, "inf", "Inf", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(tt.mode, tt.expected, tt.actual, tt.tolerance); got != tt.want {
+				t.Errorf("Compare(%q, %q, %q, %g) = %v, want %v", tt.mode, tt.expected, tt.actual, tt.tolerance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckerVerdict(t *testing.T) {
+	tests := []struct {
+		name     string
+		statusID int
+		want     bool
+		wantErr  bool
+	}{
+		{"exit 0 accepts", judge0StatusAccepted, true, false},
+		{"non-zero exit rejects", judge0StatusRuntimeErrorNZEC, false, false},
+		{"time limit is an error", judge0StatusTimeLimitExceeded, false, true},
+		{"compilation error is an error", judge0StatusCompilationError, false, true},
+		{"signal is an error", 7, false, true},
+		{"judge0 internal error", judge0StatusInternalError, false, true},
+		{"wrong answer status is an error", 4, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &judge0.Result{}
+			result.Status.ID = tt.statusID
+
+			got, err := checkerVerdict(result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkerVerdict(status %d) error = %v, wantErr %v", tt.statusID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkerVerdict(status %d) = %v, want %v", tt.statusID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionVerdict(t *testing.T) {
+	tests := []struct {
+		statusID int
+		want     string
+	}{
+		{judge0StatusAccepted, VerdictAccepted},
+		{4, VerdictInternalError}, // expected_output 을 보내지 않으므로 나오지 않아야 함
+		{judge0StatusTimeLimitExceeded, VerdictTimeLimitExceeded},
+		{judge0StatusCompilationError, VerdictCompilationError},
+		{7, VerdictRuntimeError},
+		{judge0StatusRuntimeErrorNZEC, VerdictRuntimeError},
+		{judge0StatusInternalError, VerdictInternalError},
+		{14, VerdictInternalError},
+	}
+
+	for _, tt := range tests {
+		result := &judge0.Result{}
+		result.Status.ID = tt.statusID
+		if got, _ := ExecutionVerdict(result); got != tt.want {
+			t.Errorf("ExecutionVerdict(status %d) = %q, want %q", tt.statusID, got, tt.want)
+		}
+	}
+}
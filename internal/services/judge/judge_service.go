@@ -0,0 +1,299 @@
+package judge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge0"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	instance JudgeService
+	once     sync.Once
+
+	ErrNoTestCases = errors.New("problem has no test cases")
+)
+
+// Verdict 값
+const (
+	VerdictAccepted          = "accepted"
+	VerdictWrongAnswer       = "wrong_answer"
+	VerdictTimeLimitExceeded = "time_limit_exceeded"
+	VerdictCompilationError  = "compilation_error"
+	VerdictRuntimeError      = "runtime_error"
+	VerdictInternalError     = "internal_error"
+)
+
+// Judge0 status ids, see https://ce.judge0.com/statuses
+const (
+	judge0StatusAccepted          = 3
+	judge0StatusTimeLimitExceeded = 5
+	judge0StatusCompilationError  = 6
+	judge0StatusRuntimeErrorNZEC  = 11
+	judge0StatusInternalError     = 13
+)
+
+type JudgeService struct {
+	judge0Service    judge0.Judge0Service
+	languagesService languages.LanguagesService
+	logger           *zap.SugaredLogger
+}
+
+// Verdict is the aggregated result of running a submission against the test
+// cases of a problem.
+type Verdict struct {
+	Status      string  `json:"status"`
+	PassedTests int     `json:"passed_tests"`
+	TotalTests  int     `json:"total_tests"`
+	FailedTest  int     `json:"failed_test,omitempty"` // 1부터 시작, 실패한 테스트가 없으면 0
+	Time        float64 `json:"time"`                  // 가장 오래 걸린 테스트 (초)
+	Memory      int     `json:"memory"`                // 가장 많이 사용한 테스트 (KB)
+	Message     string  `json:"message,omitempty"`
+}
+
+// TestResult is the outcome of a single test case, including the program's
+// output. Only build it for tests the user is allowed to see.
+type TestResult struct {
+	Input          string  `json:"input"`
+	ExpectedOutput string  `json:"expected_output"`
+	Stdout         string  `json:"stdout"`
+	Stderr         string  `json:"stderr,omitempty"`
+	Status         string  `json:"status"`
+	Message        string  `json:"message,omitempty"`
+	Time           float64 `json:"time"`
+	Memory         int     `json:"memory"`
+}
+
+type checkerInput struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func NewJudgeService(judge0Service judge0.Judge0Service, languagesService languages.LanguagesService, logger *zap.SugaredLogger) JudgeService {
+	once.Do(func() {
+		instance = JudgeService{
+			judge0Service:    judge0Service,
+			languagesService: languagesService,
+			logger:           logger,
+		}
+	})
+	return instance
+}
+
+// Judge runs the source code against every test case of the problem in order
+// and stops at the first one that does not pass.
+func (js *JudgeService) Judge(ctx context.Context, problem *models.Problem, languageID int, sourceCode string) (*Verdict, error) {
+	return js.JudgeTests(ctx, problem, problem.TestCases, languageID, sourceCode)
+}
+
+// JudgeTests is Judge restricted to the given test cases, e.g. the samples.
+func (js *JudgeService) JudgeTests(ctx context.Context, problem *models.Problem, tests []models.TestCase, languageID int, sourceCode string) (verdict *Verdict, err error) {
+	if len(tests) == 0 {
+		return nil, ErrNoTestCases
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, "judge.run", trace.WithAttributes(
+		attribute.Int("problem.id", int(problem.ID)),
+		attribute.Int("language.id", languageID),
+		attribute.Int("judge.tests", len(tests)),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(
+				attribute.String("judge.verdict", verdict.Status),
+				attribute.Int("judge.passed_tests", verdict.PassedTests),
+			)
+		}
+		span.End()
+	}()
+
+	if _, err := js.languagesService.Validate(ctx, languageID); err != nil {
+		return nil, err
+	}
+
+	verdict = &Verdict{Status: VerdictAccepted, TotalTests: len(tests)}
+
+	for i, tc := range tests {
+		result, err := js.judge0Service.Execute(ctx, judge0.ExecuteRequest{
+			SourceCode:   sourceCode,
+			LanguageID:   languageID,
+			Stdin:        tc.Input,
+			CPUTimeLimit: problem.TimeLimit,
+			MemoryLimit:  problem.MemoryLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		verdict.record(result)
+
+		status, message := js.testStatus(ctx, problem, tc, result)
+		if status != VerdictAccepted {
+			verdict.Status = status
+			verdict.FailedTest = i + 1
+			verdict.Message = message
+			metrics.JudgeVerdicts.WithLabelValues(verdict.Status).Inc()
+			return verdict, nil
+		}
+		verdict.PassedTests++
+	}
+
+	metrics.JudgeVerdicts.WithLabelValues(verdict.Status).Inc()
+	return verdict, nil
+}
+
+// ExecutionVerdict maps the Judge0 status of a run to a verdict without
+// looking at the output; a program that ran to completion is accepted.
+func ExecutionVerdict(result *judge0.Result) (string, string) {
+	switch {
+	case result.Status.ID == judge0StatusTimeLimitExceeded:
+		return VerdictTimeLimitExceeded, ""
+	case result.Status.ID == judge0StatusCompilationError:
+		return VerdictCompilationError, result.CompileOutput
+	case result.Status.ID >= judge0StatusInternalError:
+		return VerdictInternalError, result.Message
+	case result.Status.ID > judge0StatusCompilationError:
+		return VerdictRuntimeError, result.Stderr
+	case result.Status.ID != judge0StatusAccepted:
+		return VerdictInternalError, result.Status.Description
+	}
+	return VerdictAccepted, ""
+}
+
+// RunTests runs every given test case, without stopping at failures, and
+// returns the per-test results. It is meant for sample tests whose expected
+// output is public.
+func (js *JudgeService) RunTests(ctx context.Context, problem *models.Problem, tests []models.TestCase, languageID int, sourceCode string) ([]TestResult, error) {
+	if len(tests) == 0 {
+		return nil, ErrNoTestCases
+	}
+	if _, err := js.languagesService.Validate(ctx, languageID); err != nil {
+		return nil, err
+	}
+
+	results := make([]TestResult, 0, len(tests))
+	for _, tc := range tests {
+		result, err := js.judge0Service.Execute(ctx, judge0.ExecuteRequest{
+			SourceCode:   sourceCode,
+			LanguageID:   languageID,
+			Stdin:        tc.Input,
+			CPUTimeLimit: problem.TimeLimit,
+			MemoryLimit:  problem.MemoryLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		status, message := js.testStatus(ctx, problem, tc, result)
+		results = append(results, TestResult{
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+			Stdout:         result.Stdout,
+			Stderr:         result.Stderr,
+			Status:         status,
+			Message:        message,
+			Time:           ExecutionTime(result),
+			Memory:         result.Memory,
+		})
+	}
+
+	return results, nil
+}
+
+func (js *JudgeService) testStatus(ctx context.Context, problem *models.Problem, tc models.TestCase, result *judge0.Result) (string, string) {
+	if status, message := ExecutionVerdict(result); status != VerdictAccepted {
+		return status, message
+	}
+
+	if problem.CompareMode != models.CompareCustom {
+		if Compare(problem.CompareMode, tc.ExpectedOutput, result.Stdout, problem.FloatTolerance) {
+			return VerdictAccepted, ""
+		}
+		return VerdictWrongAnswer, ""
+	}
+
+	accepted, err := js.runChecker(ctx, problem, tc, result.Stdout)
+	if err != nil {
+		js.logger.Errorw("custom checker failed", "problemID", problem.ID, "error", err)
+		return VerdictInternalError, "checker failed"
+	}
+	if accepted {
+		return VerdictAccepted, ""
+	}
+	return VerdictWrongAnswer, ""
+}
+
+// runChecker executes the problem's checker program. The checker receives a
+// JSON object with input, expected and actual on stdin and accepts the output
+// by exiting with status 0; any other exit code rejects it.
+func (js *JudgeService) runChecker(ctx context.Context, problem *models.Problem, tc models.TestCase, actual string) (bool, error) {
+	if problem.CheckerSource == "" {
+		return false, fmt.Errorf("problem %d has no checker", problem.ID)
+	}
+
+	stdin, err := json.Marshal(checkerInput{
+		Input:    tc.Input,
+		Expected: tc.ExpectedOutput,
+		Actual:   actual,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	result, err := js.judge0Service.Execute(ctx, judge0.ExecuteRequest{
+		SourceCode: problem.CheckerSource,
+		LanguageID: problem.CheckerLanguageID,
+		Stdin:      string(stdin),
+	})
+	if err != nil {
+		return false, err
+	}
+	return checkerVerdict(result)
+}
+
+// checkerVerdict maps how the checker run ended to accept/reject. Exit code
+// 0 accepts and a non-zero exit code (Judge0 reports NZEC) rejects; anything
+// else, such as a checker that does not compile or times out, is an error.
+func checkerVerdict(result *judge0.Result) (bool, error) {
+	switch result.Status.ID {
+	case judge0StatusAccepted:
+		return true, nil
+	case judge0StatusRuntimeErrorNZEC:
+		return false, nil
+	default:
+		return false, fmt.Errorf("checker finished with status %q: %s", result.Status.Description, result.Stderr+result.CompileOutput)
+	}
+}
+
+func (v *Verdict) record(result *judge0.Result) {
+	if t := ExecutionTime(result); t > v.Time {
+		v.Time = t
+	}
+	if result.Memory > v.Memory {
+		v.Memory = result.Memory
+	}
+}
+
+// ExecutionTime parses the CPU time Judge0 reports as a decimal string.
+func ExecutionTime(result *judge0.Result) float64 {
+	t, err := strconv.ParseFloat(result.Time, 64)
+	if err != nil {
+		return 0
+	}
+	return t
+}
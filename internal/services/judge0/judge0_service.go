@@ -1,11 +1,16 @@
 package judge0
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/metrics"
 	"github.com/Dongmoon29/code_racer_api/internal/utils/client"
 	"go.uber.org/zap"
 )
@@ -28,19 +33,79 @@ func NewJudge0Service(logger *zap.SugaredLogger) Judge0Service {
 	return instance
 }
 
-func (js *Judge0Service) CreateCodeSubmission(dto dtos.CodeSubmissionRequest) (map[string]interface{}, error) {
-	submissionData := mapJudge0Request(dto)
+// submit runs a submission synchronously and records how long Judge0 took.
+func submit(ctx context.Context, submissionData map[string]interface{}) (*http.Response, error) {
+	start := time.Now()
+	response, err := client.J0Client.Do(ctx, "POST", "/submissions?base64_encoded=false&wait=true", submissionData)
 
-	response, err := client.J0Client.POST("/submissions?base64_encoded=false&wait=true", submissionData)
+	outcome := "ok"
+	if err != nil || response.StatusCode >= 300 {
+		outcome = "error"
+	}
+	metrics.Judge0RequestDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	return response, err
+}
+
+// ExecuteRequest is a single run of a program, used when judging against
+// problem test cases and when running custom checkers.
+type ExecuteRequest struct {
+	SourceCode   string
+	LanguageID   int
+	Stdin        string
+	CPUTimeLimit float64 // 초, 0 이면 Judge0 기본값
+	MemoryLimit  int     // KB, 0 이면 Judge0 기본값
+}
+
+// Result is the part of a Judge0 submission the judge looks at.
+type Result struct {
+	Stdout        string `json:"stdout"`
+	Stderr        string `json:"stderr"`
+	CompileOutput string `json:"compile_output"`
+	Message       string `json:"message"`
+	Status        struct {
+		ID          int    `json:"id"`
+		Description string `json:"description"`
+	} `json:"status"`
+	Time   string `json:"time"`   // CPU 시간 (초, 소수 문자열)
+	Memory int    `json:"memory"` // KB
+
+	// Raw is the response as Judge0 sent it, which POST /code/submit returns.
+	Raw json.RawMessage `json:"-"`
+}
+
+func (js *Judge0Service) Execute(ctx context.Context, req ExecuteRequest) (*Result, error) {
+	submissionData := mapJudge0Request(dtos.CodeSubmissionRequest{
+		SourceCode: req.SourceCode,
+		LanguageID: req.LanguageID,
+		Stdin:      req.Stdin,
+	})
+	if req.CPUTimeLimit > 0 {
+		submissionData["cpu_time_limit"] = req.CPUTimeLimit
+	}
+	if req.MemoryLimit > 0 {
+		submissionData["memory_limit"] = req.MemoryLimit
+	}
+
+	response, err := submit(ctx, submissionData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit code: %w", err)
 	}
 	defer response.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from judge0: %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result Result
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	result.Raw = body
 
-	return result, nil
+	return &result, nil
 }
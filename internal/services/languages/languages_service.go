@@ -0,0 +1,336 @@
+package languages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/client"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/languages"
+	"go.uber.org/zap"
+)
+
+var (
+	instance LanguagesService
+	once     sync.Once
+
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+)
+
+const (
+	// Judge0 에 연결할 수 없을 때 기본 목록을 얼마나 유지할지
+	fallbackExpTime = time.Minute
+	// 목록에 없는 id 로 Judge0 를 다시 조회하는 최소 간격
+	minRefreshInterval = time.Minute
+)
+
+type LanguagesService struct {
+	languageStore cache.LanguagesRedisStoreInterface
+	cacheTTL      time.Duration
+	logger        *zap.SugaredLogger
+	local         *localCatalog
+}
+
+// localCatalog keeps the last catalog in process so that validating a
+// submission does not require a Redis round trip every time.
+type localCatalog struct {
+	mu        sync.RWMutex
+	catalog   *models.LanguageCatalog
+	expiresAt time.Time
+
+	refreshMu   sync.Mutex
+	refreshedAt time.Time
+}
+
+type judge0Language struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	IsArchived bool   `json:"is_archived"`
+}
+
+// NewLanguagesService creates the languages service. languageStore may be nil
+// when Redis is disabled, in which case only the in-process cache is used.
+// The catalog is fetched again after cacheTTL, or earlier when a submission
+// uses an id the cached catalog does not know.
+func NewLanguagesService(languageStore cache.LanguagesRedisStoreInterface, cacheTTL time.Duration, logger *zap.SugaredLogger) LanguagesService {
+	once.Do(func() {
+		instance = LanguagesService{
+			languageStore: languageStore,
+			cacheTTL:      cacheTTL,
+			logger:        logger,
+			local:         &localCatalog{},
+		}
+	})
+	return instance
+}
+
+func (ls *LanguagesService) GetCatalog(ctx context.Context) (*models.LanguageCatalog, error) {
+	if catalog := ls.local.get(); catalog != nil {
+		return catalog, nil
+	}
+
+	if ls.languageStore != nil {
+		catalog, err := ls.languageStore.Get(ctx)
+		if err != nil {
+			ls.logger.Warnw("failed to read language catalog from cache", "error", err)
+		}
+		if catalog != nil {
+			ls.local.set(catalog, catalog.FetchedAt.Add(ls.cacheTTL))
+			return catalog, nil
+		}
+	}
+
+	return ls.refresh(ctx), nil
+}
+
+// refresh fetches the catalog from Judge0 and replaces both caches. When
+// Judge0 is unreachable the builtin definitions are used for a short while.
+func (ls *LanguagesService) refresh(ctx context.Context) *models.LanguageCatalog {
+	catalog, err := ls.fetchCatalog()
+	if err != nil {
+		ls.logger.Errorw("failed to fetch language catalog from judge0, using builtin definitions", "error", err)
+		catalog = builtinCatalog()
+		ls.local.set(catalog, time.Now().Add(fallbackExpTime))
+		return catalog
+	}
+
+	if ls.languageStore != nil {
+		if err := ls.languageStore.Set(ctx, catalog, ls.cacheTTL); err != nil {
+			ls.logger.Warnw("failed to cache language catalog", "error", err)
+		}
+	}
+	ls.local.set(catalog, catalog.FetchedAt.Add(ls.cacheTTL))
+
+	return catalog
+}
+
+func (ls *LanguagesService) GetLanguages(ctx context.Context, includeDisabled bool) ([]models.Language, error) {
+	catalog, err := ls.GetCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Language, 0, len(catalog.Languages))
+	for _, lang := range catalog.Languages {
+		if lang.Enabled || includeDisabled {
+			result = append(result, lang)
+		}
+	}
+	return result, nil
+}
+
+func (ls *LanguagesService) GetAbout(ctx context.Context) (map[string]interface{}, error) {
+	catalog, err := ls.GetCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if catalog.About == nil {
+		return nil, fmt.Errorf("judge0 /about is unavailable")
+	}
+	return catalog.About, nil
+}
+
+// Validate returns the language for a Judge0 language id, or
+// ErrUnsupportedLanguage when the id is unknown or disabled. An unknown id
+// may come from a Judge0 upgrade, so it triggers a refresh of the catalog
+// (at most once per minRefreshInterval).
+func (ls *LanguagesService) Validate(ctx context.Context, languageID int) (*models.Language, error) {
+	catalog, err := ls.GetCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lang, found := findLanguage(catalog, languageID)
+	if !found {
+		if catalog = ls.refreshOnMiss(ctx, catalog); catalog != nil {
+			lang, found = findLanguage(catalog, languageID)
+		}
+	}
+	if !found || !lang.Enabled {
+		return nil, ErrUnsupportedLanguage
+	}
+	return lang, nil
+}
+
+// refreshOnMiss refetches the catalog unless stale was fetched recently or
+// another request refreshed it in the meantime. It returns nil when nothing
+// was refetched.
+func (ls *LanguagesService) refreshOnMiss(ctx context.Context, stale *models.LanguageCatalog) *models.LanguageCatalog {
+	ls.local.refreshMu.Lock()
+	defer ls.local.refreshMu.Unlock()
+
+	if current := ls.local.get(); current != nil && current != stale {
+		return current
+	}
+	if time.Since(ls.local.refreshedAt) < minRefreshInterval || time.Since(stale.FetchedAt) < minRefreshInterval {
+		return nil
+	}
+	ls.local.refreshedAt = time.Now()
+	return ls.refresh(ctx)
+}
+
+func findLanguage(catalog *models.LanguageCatalog, languageID int) (*models.Language, bool) {
+	for i := range catalog.Languages {
+		if catalog.Languages[i].ID == languageID {
+			return &catalog.Languages[i], true
+		}
+	}
+	return nil, false
+}
+
+func (ls *LanguagesService) fetchCatalog() (*models.LanguageCatalog, error) {
+	res, err := client.J0Client.GET("/languages")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch /languages: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from /languages: %d", res.StatusCode)
+	}
+
+	var judge0Languages []judge0Language
+	if err := json.NewDecoder(res.Body).Decode(&judge0Languages); err != nil {
+		return nil, fmt.Errorf("failed to decode /languages: %w", err)
+	}
+
+	catalog := buildCatalog(judge0Languages)
+
+	about, err := fetchAbout()
+	if err != nil {
+		ls.logger.Warnw("failed to fetch judge0 /about", "error", err)
+	}
+	catalog.About = about
+
+	return catalog, nil
+}
+
+func fetchAbout() (map[string]interface{}, error) {
+	res, err := client.J0Client.GET("/about")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from /about: %d", res.StatusCode)
+	}
+
+	var about map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&about); err != nil {
+		return nil, err
+	}
+	return about, nil
+}
+
+// buildCatalog maps Judge0 languages onto our definitions. Every version that
+// matches a definition stays enabled, so ids clients already use keep
+// working after Judge0 adds a newer one; the newest (highest id) is marked
+// as the default. Languages without a definition are listed as disabled.
+func buildCatalog(judge0Languages []judge0Language) *models.LanguageCatalog {
+	newest := make(map[string]judge0Language)
+	for _, jl := range judge0Languages {
+		if jl.IsArchived {
+			continue
+		}
+		def, ok := languages.Match(jl.Name)
+		if !ok {
+			continue
+		}
+		if current, ok := newest[def.Slug]; !ok || jl.ID > current.ID {
+			newest[def.Slug] = jl
+		}
+	}
+
+	result := make([]models.Language, 0, len(judge0Languages))
+	for _, jl := range judge0Languages {
+		def, ok := languages.Match(jl.Name)
+		if ok && !jl.IsArchived {
+			lang := languageFromDefinition(def, jl.ID, jl.Name)
+			lang.Default = newest[def.Slug].ID == jl.ID
+			result = append(result, lang)
+			continue
+		}
+		result = append(result, models.Language{
+			ID:         jl.ID,
+			Slug:       slugify(jl.Name),
+			Name:       jl.Name,
+			Judge0Name: jl.Name,
+			Enabled:    false,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Enabled != result[j].Enabled {
+			return result[i].Enabled
+		}
+		if result[i].Slug != result[j].Slug {
+			return result[i].Slug < result[j].Slug
+		}
+		return result[i].ID > result[j].ID
+	})
+
+	return &models.LanguageCatalog{
+		Languages: result,
+		FetchedAt: time.Now(),
+	}
+}
+
+func builtinCatalog() *models.LanguageCatalog {
+	result := make([]models.Language, 0, len(languages.Definitions))
+	for _, def := range languages.Definitions {
+		lang := languageFromDefinition(def, def.DefaultID, "")
+		lang.Default = true
+		result = append(result, lang)
+	}
+	return &models.LanguageCatalog{
+		Languages: result,
+		FetchedAt: time.Now(),
+	}
+}
+
+func languageFromDefinition(def languages.Definition, id int, judge0Name string) models.Language {
+	return models.Language{
+		ID:         id,
+		Slug:       def.Slug,
+		Name:       def.Name,
+		Judge0Name: judge0Name,
+		Enabled:    true,
+		MonacoMode: def.MonacoMode,
+		Extension:  def.Extension,
+		Template:   def.Template,
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func (lc *localCatalog) get() *models.LanguageCatalog {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	if lc.catalog == nil || time.Now().After(lc.expiresAt) {
+		return nil
+	}
+	return lc.catalog
+}
+
+func (lc *localCatalog) set(catalog *models.LanguageCatalog, expiresAt time.Time) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.catalog = catalog
+	lc.expiresAt = expiresAt
+}
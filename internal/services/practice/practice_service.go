@@ -0,0 +1,177 @@
+package practice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/services/problems"
+	"github.com/Dongmoon29/code_racer_api/internal/services/submissions"
+	"go.uber.org/zap"
+)
+
+var (
+	instance PracticeService
+	once     sync.Once
+
+	ErrSessionNotFound = errors.New("practice session not found")
+)
+
+type PracticeService struct {
+	practiceRepository repositories.PracticeRepositoryInterface
+	problemsService    problems.ProblemsService
+	submissionsService submissions.SubmissionsService
+	judgeService       judge.JudgeService
+	languagesService   languages.LanguagesService
+	logger             *zap.SugaredLogger
+}
+
+type PracticeStart struct {
+	Session *models.PracticeSession `json:"session"`
+	Problem *mapper.MappedProblem   `json:"problem"`
+}
+
+type PracticeSubmitResult struct {
+	Submission      *models.Submission `json:"submission"`
+	Verdict         *judge.Verdict     `json:"verdict"`
+	SolveTimeMs     int64              `json:"solve_time_ms,omitempty"`
+	NewPersonalBest bool               `json:"new_personal_best"`
+}
+
+func NewPracticeService(
+	pr repositories.PracticeRepositoryInterface,
+	problemsService problems.ProblemsService,
+	submissionsService submissions.SubmissionsService,
+	judgeService judge.JudgeService,
+	languagesService languages.LanguagesService,
+	logger *zap.SugaredLogger,
+) PracticeService {
+	once.Do(func() {
+		instance = PracticeService{
+			practiceRepository: pr,
+			problemsService:    problemsService,
+			submissionsService: submissionsService,
+			judgeService:       judgeService,
+			languagesService:   languagesService,
+			logger:             logger,
+		}
+	})
+	return instance
+}
+
+// Start opens a problem for solo practice; the clock starts now. The problem
+// is shown in the first of the preferred locales it is written in.
+func (ps *PracticeService) Start(ctx context.Context, userID, problemID uint, locales []string) (*PracticeStart, error) {
+	problem, err := ps.problemsService.GetPublished(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := ps.practiceRepository.CreateSession(ctx, &models.PracticeSession{
+		UserID:    userID,
+		ProblemID: problem.ID,
+		StartedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PracticeStart{
+		Session: session,
+		Problem: ps.problemsService.Map(ctx, problem, locales),
+	}, nil
+}
+
+// RunSamples runs the code against the sample tests only. Nothing is stored.
+func (ps *PracticeService) RunSamples(ctx context.Context, userID, sessionID uint, dto dtos.PracticeCodeRequestDto) ([]judge.TestResult, error) {
+	session, err := ps.getSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	problem, err := ps.problemsService.GetPublished(ctx, session.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.judgeService.RunTests(ctx, problem, problem.Samples(), dto.LanguageID, dto.SourceCode)
+}
+
+// Submit judges the code against every test case. The first accepted
+// submission of a session sets its solve time, which becomes the user's
+// personal best for the problem if it is faster than the previous one.
+func (ps *PracticeService) Submit(ctx context.Context, userID, sessionID uint, dto dtos.PracticeCodeRequestDto) (*PracticeSubmitResult, error) {
+	session, err := ps.getSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ps.languagesService.Validate(ctx, dto.LanguageID); err != nil {
+		return nil, err
+	}
+
+	submitted, err := ps.submissionsService.Submit(ctx, userID, dtos.CodeSubmissionRequest{
+		SourceCode: dto.SourceCode,
+		LanguageID: dto.LanguageID,
+		ProblemID:  &session.ProblemID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PracticeSubmitResult{
+		Submission: submitted.Submission,
+		Verdict:    submitted.Verdict,
+	}
+	if submitted.Verdict.Status != judge.VerdictAccepted {
+		return result, nil
+	}
+
+	solvedAt := submitted.Submission.CreatedAt
+	first, err := ps.practiceRepository.MarkSolved(ctx, session.ID, solvedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return result, nil
+	}
+
+	result.SolveTimeMs = solvedAt.Sub(session.StartedAt).Milliseconds()
+	result.NewPersonalBest, err = ps.practiceRepository.SavePersonalBest(ctx, &models.PersonalBest{
+		UserID:       userID,
+		ProblemID:    session.ProblemID,
+		SubmissionID: submitted.Submission.ID,
+		LanguageID:   dto.LanguageID,
+		BestTimeMs:   result.SolveTimeMs,
+		AchievedAt:   solvedAt,
+	})
+	if err != nil {
+		ps.logger.Errorw("failed to save personal best", "userID", userID, "problemID", session.ProblemID, "error", err)
+	}
+
+	return result, nil
+}
+
+func (ps *PracticeService) ListPersonalBests(ctx context.Context, userID uint) ([]models.PersonalBest, error) {
+	return ps.practiceRepository.ListPersonalBests(ctx, userID)
+}
+
+func (ps *PracticeService) getSession(ctx context.Context, userID, sessionID uint) (*models.PracticeSession, error) {
+	session, err := ps.practiceRepository.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
@@ -0,0 +1,357 @@
+package problems
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/i18n"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/languages"
+)
+
+var (
+	ErrInvalidProblem  = errors.New("invalid problem")
+	ErrProblemConflict = errors.New("the problem was changed by someone else or the slug is taken")
+	ErrVersionNotFound = errors.New("problem version not found")
+	ErrNoReference     = errors.New("the problem has no reference solution")
+	ErrReferenceFailed = errors.New("the reference solution does not pass every test")
+)
+
+const (
+	maxTimeLimit   = 20.0    // 초
+	maxMemoryLimit = 1024000 // KB
+	maxTestCases   = 200
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ReferenceError reports how the reference solution failed.
+type ReferenceError struct {
+	Verdict *judge.Verdict
+}
+
+func (e *ReferenceError) Error() string {
+	return fmt.Sprintf("%s: %s on test %d", ErrReferenceFailed, e.Verdict.Status, e.Verdict.FailedTest)
+}
+
+func (e *ReferenceError) Unwrap() error {
+	return ErrReferenceFailed
+}
+
+// ProblemSummary is a problem in the authoring list.
+type ProblemSummary struct {
+	ID           uint      `json:"id"`
+	Slug         string    `json:"slug"`
+	Title        string    `json:"title"`
+	Difficulty   string    `json:"difficulty"`
+	Locales      []string  `json:"locales"`
+	IsPublished  bool      `json:"is_published"`
+	Version      int       `json:"version"`
+	TestCases    int       `json:"test_cases"`
+	HasReference bool      `json:"has_reference"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Validate checks a problem before it is saved and fills in defaults.
+func Validate(p *models.Problem) error {
+	p.Slug = strings.TrimSpace(p.Slug)
+	p.Title = strings.TrimSpace(p.Title)
+	if !slugPattern.MatchString(p.Slug) || len(p.Slug) > 64 {
+		return fmt.Errorf("%w: slug must be lowercase letters, digits and dashes (at most 64)", ErrInvalidProblem)
+	}
+	if p.Title == "" {
+		return fmt.Errorf("%w: %s has no title", ErrInvalidProblem, p.Slug)
+	}
+	if err := validateLocales(p); err != nil {
+		return err
+	}
+	if len(p.TestCases) == 0 || len(p.TestCases) > maxTestCases {
+		return fmt.Errorf("%w: %s needs 1-%d test cases", ErrInvalidProblem, p.Slug, maxTestCases)
+	}
+	if p.TimeLimit < 0 || p.TimeLimit > maxTimeLimit {
+		return fmt.Errorf("%w: %s time limit must be at most %.0f seconds", ErrInvalidProblem, p.Slug, maxTimeLimit)
+	}
+	if p.MemoryLimit < 0 || p.MemoryLimit > maxMemoryLimit {
+		return fmt.Errorf("%w: %s memory limit must be at most %d KB", ErrInvalidProblem, p.Slug, maxMemoryLimit)
+	}
+
+	switch p.CompareMode {
+	case "":
+		p.CompareMode = models.CompareExact
+	case models.CompareExact, models.CompareWhitespace, models.CompareCaseInsensitive,
+		models.CompareFloat, models.CompareUnorderedLines:
+	case models.CompareCustom:
+		if p.CheckerLanguageID == 0 || p.CheckerSource == "" {
+			return fmt.Errorf("%w: %s uses a custom checker but has none", ErrInvalidProblem, p.Slug)
+		}
+	default:
+		return fmt.Errorf("%w: %s has unknown compare mode %q", ErrInvalidProblem, p.Slug, p.CompareMode)
+	}
+
+	if (p.ReferenceLanguageID == 0) != (p.ReferenceSource == "") {
+		return fmt.Errorf("%w: %s reference solution needs both a language and source", ErrInvalidProblem, p.Slug)
+	}
+
+	seen := make(map[string]bool, len(p.Templates))
+	for _, t := range p.Templates {
+		if _, ok := languages.BySlug(t.LanguageSlug); !ok {
+			return fmt.Errorf("%w: %s has a template for unknown language %q", ErrInvalidProblem, p.Slug, t.LanguageSlug)
+		}
+		if seen[t.LanguageSlug] {
+			return fmt.Errorf("%w: %s has two %s templates", ErrInvalidProblem, p.Slug, t.LanguageSlug)
+		}
+		seen[t.LanguageSlug] = true
+	}
+
+	for i := range p.TestCases {
+		p.TestCases[i].Position = i + 1
+	}
+	return nil
+}
+
+// validateLocales normalizes the main locale and checks that every
+// translation is in another supported locale and has a title.
+func validateLocales(p *models.Problem) error {
+	if p.Locale == "" {
+		p.Locale = i18n.DefaultLocale
+	}
+	locale, ok := i18n.Normalize(p.Locale)
+	if !ok {
+		return fmt.Errorf("%w: %s has unsupported locale %q", ErrInvalidProblem, p.Slug, p.Locale)
+	}
+	p.Locale = locale
+
+	seen := map[string]bool{p.Locale: true}
+	for i := range p.Statements {
+		s := &p.Statements[i]
+		locale, ok := i18n.Normalize(s.Locale)
+		if !ok {
+			return fmt.Errorf("%w: %s has a translation in unsupported locale %q", ErrInvalidProblem, p.Slug, s.Locale)
+		}
+		if seen[locale] {
+			return fmt.Errorf("%w: %s has two %s statements", ErrInvalidProblem, p.Slug, locale)
+		}
+		seen[locale] = true
+		s.Locale = locale
+		s.Title = strings.TrimSpace(s.Title)
+		if s.Title == "" {
+			return fmt.Errorf("%w: %s %s translation has no title", ErrInvalidProblem, p.Slug, locale)
+		}
+	}
+	return nil
+}
+
+func problemFromDto(dto dtos.ProblemRequestDto) *models.Problem {
+	problem := &models.Problem{
+		Slug:                dto.Slug,
+		Title:               dto.Title,
+		Statement:           dto.Statement,
+		Locale:              dto.Locale,
+		Difficulty:          dto.Difficulty,
+		TimeLimit:           dto.TimeLimit,
+		MemoryLimit:         dto.MemoryLimit,
+		CompareMode:         models.CompareMode(dto.CompareMode),
+		FloatTolerance:      dto.FloatTolerance,
+		CheckerLanguageID:   dto.CheckerLanguageID,
+		CheckerSource:       dto.CheckerSource,
+		ReferenceLanguageID: dto.ReferenceLanguageID,
+		ReferenceSource:     dto.ReferenceSource,
+		TestCases:           make([]models.TestCase, len(dto.TestCases)),
+		Templates:           make([]models.ProblemTemplate, 0, len(dto.Templates)),
+		Statements:          make([]models.ProblemStatement, len(dto.Statements)),
+	}
+	for i, st := range dto.Statements {
+		problem.Statements[i] = models.ProblemStatement{
+			Locale:    st.Locale,
+			Title:     st.Title,
+			Statement: st.Statement,
+		}
+	}
+	for i, tc := range dto.TestCases {
+		problem.TestCases[i] = models.TestCase{
+			Input:          tc.Input,
+			ExpectedOutput: tc.ExpectedOutput,
+			IsSample:       tc.IsSample,
+		}
+	}
+	for slug, code := range dto.Templates {
+		problem.Templates = append(problem.Templates, models.ProblemTemplate{LanguageSlug: slug, Code: code})
+	}
+	sort.Slice(problem.Templates, func(i, j int) bool {
+		return problem.Templates[i].LanguageSlug < problem.Templates[j].LanguageSlug
+	})
+	return problem
+}
+
+// ListForAuthoring returns every problem, published or not.
+func (ps *ProblemsService) ListForAuthoring(ctx context.Context) ([]ProblemSummary, error) {
+	problems, err := ps.problemRepository.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ProblemSummary, len(problems))
+	for i, p := range problems {
+		summaries[i] = ProblemSummary{
+			ID:           p.ID,
+			Slug:         p.Slug,
+			Title:        p.Title,
+			Difficulty:   p.Difficulty,
+			Locales:      p.Locales(),
+			IsPublished:  p.IsPublished,
+			Version:      p.Version,
+			TestCases:    len(p.TestCases),
+			HasReference: p.ReferenceSource != "",
+			UpdatedAt:    p.UpdatedAt,
+		}
+	}
+	return summaries, nil
+}
+
+// GetForAuthoring returns the full problem, hidden tests, checker and
+// reference solution included.
+func (ps *ProblemsService) GetForAuthoring(ctx context.Context, problemID uint) (*models.ProblemSnapshot, error) {
+	problem, err := ps.problemRepository.GetByID(ctx, problemID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, err
+	}
+	return models.NewProblemSnapshot(problem), nil
+}
+
+// CreateProblem stores a new, unpublished problem.
+func (ps *ProblemsService) CreateProblem(ctx context.Context, authorID uint, dto dtos.ProblemRequestDto) (*models.ProblemSnapshot, error) {
+	problem := problemFromDto(dto)
+	if err := Validate(problem); err != nil {
+		return nil, err
+	}
+
+	if err := ps.problemRepository.Create(ctx, problem, &authorID); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, ErrProblemConflict
+		}
+		return nil, err
+	}
+
+	ps.logger.Infow("problem created", "problemID", problem.ID, "slug", problem.Slug, "authorID", authorID)
+	return models.NewProblemSnapshot(problem), nil
+}
+
+// UpdateProblem replaces the problem's content and stores a new version. The
+// published state is kept.
+func (ps *ProblemsService) UpdateProblem(ctx context.Context, authorID uint, problemID uint, dto dtos.ProblemRequestDto) (*models.ProblemSnapshot, error) {
+	problem := problemFromDto(dto)
+	if err := Validate(problem); err != nil {
+		return nil, err
+	}
+	problem.ID = problemID
+
+	if err := ps.problemRepository.Update(ctx, problem, dto.Version, &authorID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, ErrProblemNotFound
+		case errors.Is(err, repositories.ErrConflict):
+			return nil, ErrProblemConflict
+		}
+		return nil, err
+	}
+
+	ps.logger.Infow("problem updated", "problemID", problemID, "version", problem.Version, "authorID", authorID)
+	return models.NewProblemSnapshot(problem), nil
+}
+
+// SetPublished publishes or unpublishes a problem. A problem with a
+// reference solution is only published if the solution passes every test.
+func (ps *ProblemsService) SetPublished(ctx context.Context, problemID uint, published bool) error {
+	if published {
+		problem, err := ps.problemRepository.GetByID(ctx, problemID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrProblemNotFound
+			}
+			return err
+		}
+		if problem.ReferenceSource != "" {
+			if _, err := ps.VerifyReference(ctx, problem); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ps.problemRepository.SetPublished(ctx, problemID, published); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrProblemNotFound
+		}
+		return err
+	}
+
+	ps.logger.Infow("problem visibility changed", "problemID", problemID, "published", published)
+	return nil
+}
+
+// VerifyReference runs the reference solution against every test case. It
+// returns a *ReferenceError when a test does not pass.
+func (ps *ProblemsService) VerifyReference(ctx context.Context, problem *models.Problem) (*judge.Verdict, error) {
+	if problem.ReferenceSource == "" {
+		return nil, ErrNoReference
+	}
+
+	verdict, err := ps.judgeService.Judge(ctx, problem, problem.ReferenceLanguageID, problem.ReferenceSource)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Status != judge.VerdictAccepted {
+		return verdict, &ReferenceError{Verdict: verdict}
+	}
+	return verdict, nil
+}
+
+// VerifyStoredReference is VerifyReference for a saved problem.
+func (ps *ProblemsService) VerifyStoredReference(ctx context.Context, problemID uint) (*judge.Verdict, error) {
+	problem, err := ps.problemRepository.GetByID(ctx, problemID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, err
+	}
+	return ps.VerifyReference(ctx, problem)
+}
+
+func (ps *ProblemsService) ListVersions(ctx context.Context, problemID uint) ([]models.ProblemVersion, error) {
+	if _, err := ps.problemRepository.GetByID(ctx, problemID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, err
+	}
+	return ps.problemRepository.ListVersions(ctx, problemID)
+}
+
+// GetVersion returns the content of a past version.
+func (ps *ProblemsService) GetVersion(ctx context.Context, problemID uint, version int) (*models.ProblemSnapshot, error) {
+	v, err := ps.problemRepository.GetVersion(ctx, problemID, version)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	var snapshot models.ProblemSnapshot
+	if err := json.Unmarshal([]byte(v.Snapshot), &snapshot); err != nil {
+		return nil, fmt.Errorf("corrupt snapshot of problem %d version %d: %w", problemID, version, err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,419 @@
+package problems
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/languages"
+	"gopkg.in/yaml.v3"
+)
+
+// A problem package is a directory, zipped for import and export:
+//
+//	problem.yaml        manifest (packageManifest)
+//	statement.md        statement markdown, in the manifest's locale
+//	statement.<locale>.md  translations listed in the manifest
+//	tests/<name>.in     test input, run in name order
+//	tests/<name>.out    expected output
+//	templates/<slug>.<ext>  starter code per language
+//	solution.<ext>      reference solution
+//	checker.<ext>       custom checker, when compare_mode is custom
+//
+// The files may also sit inside a single top-level directory.
+const (
+	manifestFile     = "problem.yaml"
+	defaultStatement = "statement.md"
+
+	MaxPackageSize  = 32 << 20 // zip 파일 크기
+	maxUnpackedSize = 64 << 20
+	maxPackageFiles = 2 * (maxTestCases + 32)
+)
+
+var ErrInvalidPackage = errors.New("invalid problem package")
+
+type packageManifest struct {
+	Slug           string                        `yaml:"slug"`
+	Title          string                        `yaml:"title"`
+	Difficulty     string                        `yaml:"difficulty,omitempty"`
+	TimeLimit      float64                       `yaml:"time_limit,omitempty"`
+	MemoryLimit    int                           `yaml:"memory_limit,omitempty"`
+	CompareMode    string                        `yaml:"compare_mode,omitempty"`
+	FloatTolerance float64                       `yaml:"float_tolerance,omitempty"`
+	Statement      string                        `yaml:"statement,omitempty"`
+	Locale         string                        `yaml:"locale,omitempty"`
+	Translations   map[string]packageTranslation `yaml:"translations,omitempty"` // locale -> 번역
+	Samples        []string                      `yaml:"samples,omitempty"`      // 공개할 테스트 이름
+	Solution       *packageSource                `yaml:"solution"`
+	Checker        *packageSource                `yaml:"checker,omitempty"`
+}
+
+type packageTranslation struct {
+	Title     string `yaml:"title"`
+	Statement string `yaml:"statement"` // 파일 경로
+}
+
+type packageSource struct {
+	Language string `yaml:"language"` // language slug, e.g. python
+	File     string `yaml:"file"`
+}
+
+// ImportedPackage is the outcome of a package import.
+type ImportedPackage struct {
+	Problem *models.ProblemSnapshot `json:"problem"`
+	Created bool                    `json:"created"`
+	Verdict *judge.Verdict          `json:"verdict"`
+}
+
+// ImportPackage reads a zipped package, checks that its reference solution
+// passes every test and creates or replaces the problem with the same slug.
+// A replaced problem keeps its published state.
+func (ps *ProblemsService) ImportPackage(ctx context.Context, authorID uint, data []byte) (*ImportedPackage, error) {
+	files, err := unzipPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	problem, err := ps.parsePackage(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(problem); err != nil {
+		return nil, err
+	}
+
+	verdict, err := ps.VerifyReference(ctx, problem)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := ps.problemRepository.Import(ctx, problem, &authorID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil, ErrProblemConflict
+		}
+		return nil, err
+	}
+
+	ps.logger.Infow("problem package imported", "problemID", problem.ID, "slug", problem.Slug, "created", created, "authorID", authorID)
+	return &ImportedPackage{
+		Problem: models.NewProblemSnapshot(problem),
+		Created: created,
+		Verdict: verdict,
+	}, nil
+}
+
+// ExportPackage writes the problem as a zipped package.
+func (ps *ProblemsService) ExportPackage(ctx context.Context, problemID uint, w io.Writer) (*models.Problem, error) {
+	problem, err := ps.problemRepository.GetByID(ctx, problemID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, err
+	}
+
+	manifest := packageManifest{
+		Slug:           problem.Slug,
+		Title:          problem.Title,
+		Difficulty:     problem.Difficulty,
+		TimeLimit:      problem.TimeLimit,
+		MemoryLimit:    problem.MemoryLimit,
+		CompareMode:    string(problem.CompareMode),
+		FloatTolerance: problem.FloatTolerance,
+		Statement:      defaultStatement,
+		Locale:         problem.Locale,
+	}
+
+	files := map[string]string{defaultStatement: problem.Statement}
+	for _, st := range problem.Statements {
+		if manifest.Translations == nil {
+			manifest.Translations = make(map[string]packageTranslation, len(problem.Statements))
+		}
+		file := "statement." + st.Locale + ".md"
+		files[file] = st.Statement
+		manifest.Translations[st.Locale] = packageTranslation{Title: st.Title, Statement: file}
+	}
+	for i, tc := range problem.TestCases {
+		name := fmt.Sprintf("%03d", i+1)
+		files["tests/"+name+".in"] = tc.Input
+		files["tests/"+name+".out"] = tc.ExpectedOutput
+		if tc.IsSample {
+			manifest.Samples = append(manifest.Samples, name)
+		}
+	}
+	for _, t := range problem.Templates {
+		files["templates/"+t.LanguageSlug+extensionOf(t.LanguageSlug)] = t.Code
+	}
+
+	if problem.ReferenceSource != "" {
+		src, err := ps.exportSource(ctx, "solution", problem.ReferenceLanguageID, problem.ReferenceSource, files)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Solution = src
+	}
+	if problem.CheckerSource != "" {
+		src, err := ps.exportSource(ctx, "checker", problem.CheckerLanguageID, problem.CheckerSource, files)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Checker = src
+	}
+
+	out, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	files[manifestFile] = string(out)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.Create(problem.Slug + "/" + name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, files[name]); err != nil {
+			return nil, err
+		}
+	}
+	return problem, zw.Close()
+}
+
+func (ps *ProblemsService) exportSource(ctx context.Context, base string, languageID int, source string, files map[string]string) (*packageSource, error) {
+	slug, err := ps.languageSlug(ctx, languageID)
+	if err != nil {
+		return nil, err
+	}
+	file := base + extensionOf(slug)
+	files[file] = source
+	return &packageSource{Language: slug, File: file}, nil
+}
+
+func (ps *ProblemsService) parsePackage(ctx context.Context, files map[string]string) (*models.Problem, error) {
+	raw, ok := files[manifestFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing", ErrInvalidPackage, manifestFile)
+	}
+	var manifest packageManifest
+	if err := yaml.Unmarshal([]byte(raw), &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, manifestFile, err)
+	}
+
+	statementFile := manifest.Statement
+	if statementFile == "" {
+		statementFile = defaultStatement
+	}
+	statement, ok := files[statementFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: statement %s is missing", ErrInvalidPackage, statementFile)
+	}
+
+	problem := &models.Problem{
+		Slug:           manifest.Slug,
+		Title:          manifest.Title,
+		Statement:      statement,
+		Locale:         manifest.Locale,
+		Difficulty:     manifest.Difficulty,
+		TimeLimit:      manifest.TimeLimit,
+		MemoryLimit:    manifest.MemoryLimit,
+		CompareMode:    models.CompareMode(manifest.CompareMode),
+		FloatTolerance: manifest.FloatTolerance,
+	}
+
+	for locale, tr := range manifest.Translations {
+		text, ok := files[tr.Statement]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s statement %q is missing", ErrInvalidPackage, locale, tr.Statement)
+		}
+		problem.Statements = append(problem.Statements, models.ProblemStatement{
+			Locale:    locale,
+			Title:     tr.Title,
+			Statement: text,
+		})
+	}
+	sort.Slice(problem.Statements, func(i, j int) bool {
+		return problem.Statements[i].Locale < problem.Statements[j].Locale
+	})
+
+	if manifest.Solution == nil {
+		return nil, fmt.Errorf("%w: a reference solution is required", ErrInvalidPackage)
+	}
+	var err error
+	problem.ReferenceLanguageID, problem.ReferenceSource, err = ps.readSource(ctx, "solution", manifest.Solution, files)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Checker != nil {
+		problem.CheckerLanguageID, problem.CheckerSource, err = ps.readSource(ctx, "checker", manifest.Checker, files)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	samples := make(map[string]bool, len(manifest.Samples))
+	for _, name := range manifest.Samples {
+		samples[name] = true
+	}
+
+	var tests []string
+	for name := range files {
+		dir, file := path.Split(name)
+		if dir == "tests/" && strings.HasSuffix(file, ".in") {
+			tests = append(tests, strings.TrimSuffix(file, ".in"))
+		}
+	}
+	sort.Strings(tests)
+	for _, name := range tests {
+		expected, ok := files["tests/"+name+".out"]
+		if !ok {
+			return nil, fmt.Errorf("%w: tests/%s.out is missing", ErrInvalidPackage, name)
+		}
+		problem.TestCases = append(problem.TestCases, models.TestCase{
+			Input:          files["tests/"+name+".in"],
+			ExpectedOutput: expected,
+			IsSample:       samples[name],
+		})
+		delete(samples, name)
+	}
+	for name := range samples {
+		return nil, fmt.Errorf("%w: sample %q has no test files", ErrInvalidPackage, name)
+	}
+
+	for name, code := range files {
+		dir, file := path.Split(name)
+		if dir != "templates/" || file == "" {
+			continue
+		}
+		slug := strings.TrimSuffix(file, path.Ext(file))
+		problem.Templates = append(problem.Templates, models.ProblemTemplate{LanguageSlug: slug, Code: code})
+	}
+	sort.Slice(problem.Templates, func(i, j int) bool {
+		return problem.Templates[i].LanguageSlug < problem.Templates[j].LanguageSlug
+	})
+
+	return problem, nil
+}
+
+func (ps *ProblemsService) readSource(ctx context.Context, what string, src *packageSource, files map[string]string) (int, string, error) {
+	source, ok := files[src.File]
+	if !ok || src.File == "" {
+		return 0, "", fmt.Errorf("%w: %s file %q is missing", ErrInvalidPackage, what, src.File)
+	}
+	id, err := ps.languageID(ctx, src.Language)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, source, nil
+}
+
+// languageID maps a language slug to the Judge0 id of its newest version on
+// this instance.
+func (ps *ProblemsService) languageID(ctx context.Context, slug string) (int, error) {
+	langs, err := ps.languagesService.GetLanguages(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	for _, lang := range langs {
+		if lang.Slug == slug && lang.Default {
+			return lang.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown language %q", ErrInvalidPackage, slug)
+}
+
+func (ps *ProblemsService) languageSlug(ctx context.Context, id int) (string, error) {
+	langs, err := ps.languagesService.GetLanguages(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	for _, lang := range langs {
+		if lang.ID == id {
+			return lang.Slug, nil
+		}
+	}
+	return "", fmt.Errorf("language %d is not in the catalog", id)
+}
+
+func extensionOf(slug string) string {
+	if def, ok := languages.BySlug(slug); ok && def.Extension != "" {
+		return def.Extension
+	}
+	return ".txt"
+}
+
+// unzipPackage returns the package files by path, without the optional
+// top-level directory.
+func unzipPackage(data []byte) (map[string]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	var regular []*zip.File
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() {
+			regular = append(regular, f)
+		}
+	}
+	if len(regular) > maxPackageFiles {
+		return nil, fmt.Errorf("%w: more than %d files", ErrInvalidPackage, maxPackageFiles)
+	}
+
+	// problem.yaml이 최상위 디렉터리 안에 있으면 그 디렉터리를 벗겨낸다
+	prefix := ""
+	for _, f := range regular {
+		name := path.Clean(f.Name)
+		if name == manifestFile {
+			prefix = ""
+			break
+		}
+		if path.Base(name) == manifestFile && strings.Count(name, "/") == 1 {
+			prefix = path.Dir(name) + "/"
+		}
+	}
+
+	files := make(map[string]string, len(regular))
+	var total uint64
+	for _, f := range regular {
+		name := path.Clean(f.Name)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		total += f.UncompressedSize64
+		if total > maxUnpackedSize {
+			return nil, fmt.Errorf("%w: more than %d MB unpacked", ErrInvalidPackage, maxUnpackedSize>>20)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, f.Name, err)
+		}
+		// 헤더의 크기를 믿지 않고 실제로 읽는 양도 제한
+		content, err := io.ReadAll(io.LimitReader(rc, int64(f.UncompressedSize64)+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, f.Name, err)
+		}
+		if uint64(len(content)) > f.UncompressedSize64 {
+			return nil, fmt.Errorf("%w: %s is larger than declared", ErrInvalidPackage, f.Name)
+		}
+		files[strings.TrimPrefix(name, prefix)] = string(content)
+	}
+	return files, nil
+}
@@ -0,0 +1,152 @@
+package problems
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/markdown"
+	"go.uber.org/zap"
+)
+
+var (
+	instance ProblemsService
+	once     sync.Once
+
+	ErrProblemNotFound = errors.New("problem not found")
+)
+
+type ProblemsService struct {
+	problemRepository    repositories.ProblemRepositoryInterface
+	submissionRepository repositories.SubmissionRepositoryInterface
+	practiceRepository   repositories.PracticeRepositoryInterface
+	languagesService     languages.LanguagesService
+	judgeService         judge.JudgeService
+	logger               *zap.SugaredLogger
+}
+
+func NewProblemsService(
+	pr repositories.ProblemRepositoryInterface,
+	sr repositories.SubmissionRepositoryInterface,
+	prr repositories.PracticeRepositoryInterface,
+	languagesService languages.LanguagesService,
+	judgeService judge.JudgeService,
+	logger *zap.SugaredLogger,
+) ProblemsService {
+	once.Do(func() {
+		instance = ProblemsService{
+			problemRepository:    pr,
+			submissionRepository: sr,
+			practiceRepository:   prr,
+			languagesService:     languagesService,
+			judgeService:         judgeService,
+			logger:               logger,
+		}
+	})
+	return instance
+}
+
+// List returns the published problems with titles in the preferred locales.
+func (ps *ProblemsService) List(ctx context.Context, locales []string) ([]*mapper.MappedProblem, error) {
+	problems, err := ps.problemRepository.ListPublished(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*mapper.MappedProblem, 0, len(problems))
+	for i := range problems {
+		result = append(result, mapper.ProblemMapper(&problems[i], locales))
+	}
+	return result, nil
+}
+
+// GetPublished loads a published problem, hidden test cases included, so the
+// caller can judge against it. Unpublished problems are reported as missing.
+func (ps *ProblemsService) GetPublished(ctx context.Context, problemID uint) (*models.Problem, error) {
+	problem, err := ps.problemRepository.GetByID(ctx, problemID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, err
+	}
+	if !problem.IsPublished {
+		return nil, ErrProblemNotFound
+	}
+	return problem, nil
+}
+
+// Get returns the public view of a problem with starter code for every
+// enabled language.
+func (ps *ProblemsService) Get(ctx context.Context, problemID uint, locales []string) (*mapper.MappedProblem, error) {
+	problem, err := ps.GetPublished(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	return ps.Map(ctx, problem, locales), nil
+}
+
+// Map converts a problem to its public view in the first preferred locale it
+// is written in, rendering the statement and filling in the default template
+// of each enabled language the problem has no template for.
+func (ps *ProblemsService) Map(ctx context.Context, problem *models.Problem, locales []string) *mapper.MappedProblem {
+	mapped := mapper.ProblemMapper(problem, locales)
+
+	statementHTML, err := markdown.Render(mapped.Statement)
+	if err != nil {
+		ps.logger.Warnw("failed to render statement", "problemID", problem.ID, "locale", mapped.Locale, "error", err)
+	}
+	mapped.StatementHTML = statementHTML
+
+	langs, err := ps.languagesService.GetLanguages(ctx, false)
+	if err != nil {
+		ps.logger.Warnw("failed to load languages for templates", "error", err)
+		return mapped
+	}
+
+	if mapped.Templates == nil {
+		mapped.Templates = make(map[string]string, len(langs))
+	}
+	for _, lang := range langs {
+		if _, ok := mapped.Templates[lang.Slug]; !ok && lang.Template != "" {
+			mapped.Templates[lang.Slug] = lang.Template
+		}
+	}
+	return mapped
+}
+
+func (ps *ProblemsService) GetStats(ctx context.Context, problemID uint) (*models.ProblemStats, error) {
+	if _, err := ps.GetPublished(ctx, problemID); err != nil {
+		return nil, err
+	}
+
+	counts, err := ps.submissionRepository.CountByVerdict(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	solvers, medianMs, err := ps.practiceRepository.SolveTimeStats(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.ProblemStats{
+		ProblemID:           problemID,
+		AcceptedSubmissions: counts[judge.VerdictAccepted],
+		Solvers:             solvers,
+		MedianSolveTimeMs:   medianMs,
+	}
+	for _, count := range counts {
+		stats.TotalSubmissions += count
+	}
+	if stats.TotalSubmissions > 0 {
+		stats.AcceptanceRate = float64(stats.AcceptedSubmissions) / float64(stats.TotalSubmissions)
+	}
+
+	return stats, nil
+}
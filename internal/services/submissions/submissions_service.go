@@ -0,0 +1,205 @@
+package submissions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge"
+	"github.com/Dongmoon29/code_racer_api/internal/services/judge0"
+	"go.uber.org/zap"
+)
+
+var (
+	instance SubmissionsService
+	once     sync.Once
+
+	ErrProblemNotFound = errors.New("problem not found")
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+type SubmissionsService struct {
+	submissionRepository repositories.SubmissionRepositoryInterface
+	problemRepository    repositories.ProblemRepositoryInterface
+	roleRepository       repositories.RoleRepositoryInterface
+	judge0Service        judge0.Judge0Service
+	judgeService         judge.JudgeService
+	logger               *zap.SugaredLogger
+}
+
+// SubmitResult holds either the raw run result, for code submitted without a
+// problem, or the verdict against the problem's test cases.
+type SubmitResult struct {
+	Submission *models.Submission `json:"submission"`
+	Result     *judge0.Result     `json:"result,omitempty"`
+	Verdict    *judge.Verdict     `json:"verdict,omitempty"`
+}
+
+type SubmissionPage struct {
+	Submissions []models.Submission `json:"submissions"`
+	Total       int64               `json:"total"`
+	Page        int                 `json:"page"`
+	PerPage     int                 `json:"per_page"`
+}
+
+func NewSubmissionsService(
+	sr repositories.SubmissionRepositoryInterface,
+	pr repositories.ProblemRepositoryInterface,
+	rr repositories.RoleRepositoryInterface,
+	judge0Service judge0.Judge0Service,
+	judgeService judge.JudgeService,
+	logger *zap.SugaredLogger,
+) SubmissionsService {
+	once.Do(func() {
+		instance = SubmissionsService{
+			submissionRepository: sr,
+			problemRepository:    pr,
+			roleRepository:       rr,
+			judge0Service:        judge0Service,
+			judgeService:         judgeService,
+			logger:               logger,
+		}
+	})
+	return instance
+}
+
+// Submit runs the code, against the problem's test cases when a problem is
+// given, and stores the submission whatever the outcome.
+func (ss *SubmissionsService) Submit(ctx context.Context, userID uint, dto dtos.CodeSubmissionRequest) (*SubmitResult, error) {
+	submission := &models.Submission{
+		UserID:     userID,
+		ProblemID:  dto.ProblemID,
+		LanguageID: dto.LanguageID,
+		SourceHash: HashSource(dto.SourceCode),
+		SourceCode: dto.SourceCode,
+	}
+	result := &SubmitResult{Submission: submission}
+
+	var runErr error
+	if dto.ProblemID != nil {
+		problem, err := ss.problemRepository.GetByID(ctx, *dto.ProblemID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrProblemNotFound
+			}
+			return nil, err
+		}
+		if !problem.IsPublished {
+			return nil, ErrProblemNotFound
+		}
+
+		result.Verdict, runErr = ss.judgeService.Judge(ctx, problem, dto.LanguageID, dto.SourceCode)
+		if runErr == nil {
+			submission.Verdict = result.Verdict.Status
+			submission.Time = result.Verdict.Time
+			submission.Memory = result.Verdict.Memory
+		}
+	} else {
+		result.Result, runErr = ss.judge0Service.Execute(ctx, judge0.ExecuteRequest{
+			SourceCode: dto.SourceCode,
+			LanguageID: dto.LanguageID,
+			Stdin:      dto.Stdin,
+		})
+		if runErr == nil {
+			submission.Verdict, _ = judge.ExecutionVerdict(result.Result)
+			submission.Time = judge.ExecutionTime(result.Result)
+			submission.Memory = result.Result.Memory
+		}
+	}
+
+	if runErr != nil {
+		submission.Verdict = judge.VerdictInternalError
+	}
+
+	if _, err := ss.save(submission); err != nil {
+		ss.logger.Errorw("failed to store submission", "userID", userID, "error", err)
+		if runErr == nil {
+			return nil, err
+		}
+	}
+
+	if runErr != nil {
+		return nil, runErr
+	}
+	return result, nil
+}
+
+func (ss *SubmissionsService) save(submission *models.Submission) (*models.Submission, error) {
+	// 요청이 취소되더라도 실행이 끝난 제출은 저장한다
+	ctx, cancel := context.WithTimeout(context.Background(), repositories.QueryTimeoutDuration)
+	defer cancel()
+
+	return ss.submissionRepository.Create(ctx, submission)
+}
+
+// List returns the user's own submissions.
+func (ss *SubmissionsService) List(ctx context.Context, userID uint, query dtos.SubmissionListQuery) (*SubmissionPage, error) {
+	page, perPage := query.Page, query.PerPage
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	submissions, total, err := ss.submissionRepository.List(ctx, repositories.SubmissionFilter{
+		UserID:     userID,
+		ProblemID:  query.ProblemID,
+		LanguageID: query.LanguageID,
+		Verdict:    query.Verdict,
+		Limit:      perPage,
+		Offset:     (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubmissionPage{
+		Submissions: submissions,
+		Total:       total,
+		Page:        page,
+		PerPage:     perPage,
+	}, nil
+}
+
+// Get returns a submission with its source code. Only the owner and roles
+// allowed to read all submissions may read it; for anyone else it does not
+// exist, so ids of other users' submissions cannot be probed.
+func (ss *SubmissionsService) Get(ctx context.Context, user *mapper.MappedUser, submissionID uint) (*models.Submission, error) {
+	submission, err := ss.submissionRepository.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.UserID == user.ID {
+		return submission, nil
+	}
+
+	role, err := ss.roleRepository.GetByID(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.HasPermission(models.PermissionSubmissionReadAll) {
+		return nil, repositories.ErrNotFound
+	}
+
+	return submission, nil
+}
+
+func HashSource(sourceCode string) string {
+	sum := sha256.Sum256([]byte(sourceCode))
+	return hex.EncodeToString(sum[:])
+}
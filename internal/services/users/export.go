@@ -0,0 +1,83 @@
+package users
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/models"
+)
+
+// UserExport is everything stored about a user, for the personal data
+// export.
+type UserExport struct {
+	ExportedAt  time.Time             `json:"exported_at"`
+	Profile     *mapper.MappedUser    `json:"profile"`
+	Rating      int                   `json:"rating"`
+	Identities  []models.UserIdentity `json:"identities"`
+	Submissions []models.Submission   `json:"submissions"`
+	Matches     []models.Match        `json:"matches"`
+}
+
+// ExportData collects the user's profile, linked accounts, submissions and
+// matches.
+func (us *UsersService) ExportData(ctx context.Context, userID uint) (*UserExport, error) {
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := us.userRepository.ListIdentities(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identities: %w", err)
+	}
+	submissions, err := us.submissionRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load submissions: %w", err)
+	}
+	matches, err := us.matchRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load matches: %w", err)
+	}
+
+	return &UserExport{
+		ExportedAt:  time.Now().UTC(),
+		Profile:     mapper.UserMapper(user),
+		Rating:      user.Rating,
+		Identities:  identities,
+		Submissions: submissions,
+		Matches:     matches,
+	}, nil
+}
+
+// WriteZip writes the export as a ZIP archive with one JSON file per section.
+func (e *UserExport) WriteZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", map[string]interface{}{"exported_at": e.ExportedAt, "profile": e.Profile, "rating": e.Rating}},
+		{"identities.json", e.Identities},
+		{"submissions.json", e.Submissions},
+		{"matches.json", e.Matches},
+	}
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: e.ExportedAt})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,46 @@
+package users
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeDeletedAccounts anonymizes the accounts whose deletion grace period has
+// ended. It returns how many accounts were anonymized.
+func (us *UsersService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	ids, err := us.userRepository.ListDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := us.userRepository.Delete(ctx, int64(id)); err != nil {
+			us.logger.Errorw("failed to anonymize account", "userID", id, "error", err)
+			continue
+		}
+		us.invalidateCache(ctx, id)
+		purged++
+		us.logger.Infow("account anonymized", "userID", id)
+	}
+	return purged, nil
+}
+
+// RunAccountPurger calls PurgeDeletedAccounts every interval until ctx is
+// done.
+func (us *UsersService) RunAccountPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := us.PurgeDeletedAccounts(ctx); err != nil {
+			us.logger.Errorw("failed to purge deleted accounts", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"go.uber.org/zap"
+)
+
+// fakeUserRepository 는 탈퇴 예정 계정 목록과 익명화 호출만 구현
+type fakeUserRepository struct {
+	repositories.UserRepositoryInterface
+	due     []uint
+	failing map[int64]bool
+	deleted []int64
+	before  time.Time
+}
+
+func (r *fakeUserRepository) ListDueForDeletion(_ context.Context, t time.Time) ([]uint, error) {
+	r.before = t
+	return r.due, nil
+}
+
+func (r *fakeUserRepository) Delete(_ context.Context, id int64) error {
+	if r.failing[id] {
+		return errors.New("connection reset")
+	}
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type fakeUserStore struct {
+	deleted []int
+}
+
+func (s *fakeUserStore) Get(context.Context, int) (*mapper.MappedUser, error) { return nil, nil }
+func (s *fakeUserStore) Set(context.Context, *mapper.MappedUser) error        { return nil }
+func (s *fakeUserStore) Delete(_ context.Context, id int) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		due         []uint
+		failing     map[int64]bool
+		wantPurged  int
+		wantDeleted []int64
+		wantCleared []int
+	}{
+		{"nothing due", nil, nil, 0, nil, nil},
+		{"all due accounts", []uint{3, 5}, nil, 2, []int64{3, 5}, []int{3, 5}},
+		// 한 계정이 실패해도 나머지는 계속 처리하고, 실패한 계정의 캐시는 유지
+		{"one failure", []uint{3, 4, 5}, map[int64]bool{4: true}, 2, []int64{3, 5}, []int{3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepository{due: tt.due, failing: tt.failing}
+			store := &fakeUserStore{}
+			us := &UsersService{userRepository: repo, userStore: store, logger: zap.NewNop().Sugar()}
+
+			purged, err := us.PurgeDeletedAccounts(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if purged != tt.wantPurged {
+				t.Errorf("purged = %d, want %d", purged, tt.wantPurged)
+			}
+			if !reflect.DeepEqual(repo.deleted, tt.wantDeleted) {
+				t.Errorf("anonymized %v, want %v", repo.deleted, tt.wantDeleted)
+			}
+			if !reflect.DeepEqual(store.deleted, tt.wantCleared) {
+				t.Errorf("invalidated cache of %v, want %v", store.deleted, tt.wantCleared)
+			}
+			if time.Since(repo.before) > time.Minute {
+				t.Errorf("listed accounts due before %s, want now", repo.before)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Dongmoon29/code_racer_api/internal/dtos"
+	"github.com/Dongmoon29/code_racer_api/internal/mapper"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories"
+	"github.com/Dongmoon29/code_racer_api/internal/repositories/cache"
+	"github.com/Dongmoon29/code_racer_api/internal/services/languages"
+	utils "github.com/Dongmoon29/code_racer_api/internal/utils/auth"
+	"github.com/Dongmoon29/code_racer_api/internal/utils/i18n"
+	langdefs "github.com/Dongmoon29/code_racer_api/internal/utils/languages"
+	"go.uber.org/zap"
+)
+
+var (
+	instance UsersService
+	once     sync.Once
+
+	ErrUserNotFound   = errors.New("user not found")
+	ErrUsernameTaken  = errors.New("username is already taken")
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+const (
+	maxDisplayNameLength = 50
+	maxBioLength         = 500
+	maxAvatarURLLength   = 512
+)
+
+// Monaco 기본 테마
+var editorThemes = map[string]bool{"vs": true, "vs-dark": true, "hc-black": true, "hc-light": true}
+
+type UsersService struct {
+	userRepository       repositories.UserRepositoryInterface
+	submissionRepository repositories.SubmissionRepositoryInterface
+	matchRepository      repositories.MatchRepositoryInterface
+	languagesService     languages.LanguagesService
+	userStore            cache.UsersRedisStoreInterface
+	logger               *zap.SugaredLogger
+}
+
+// NewUsersService creates the users service. userStore may be nil when Redis
+// is disabled.
+func NewUsersService(
+	ur repositories.UserRepositoryInterface,
+	sr repositories.SubmissionRepositoryInterface,
+	mr repositories.MatchRepositoryInterface,
+	languagesService languages.LanguagesService,
+	userStore cache.UsersRedisStoreInterface,
+	logger *zap.SugaredLogger,
+) UsersService {
+	once.Do(func() {
+		instance = UsersService{
+			userRepository:       ur,
+			submissionRepository: sr,
+			matchRepository:      mr,
+			languagesService:     languagesService,
+			userStore:            userStore,
+			logger:               logger,
+		}
+	})
+	return instance
+}
+
+// UpdateProfile applies a partial profile update and drops the cached user so
+// the next request sees the change.
+func (us *UsersService) UpdateProfile(ctx context.Context, userID uint, dto dtos.UpdateProfileRequestDto) (*mapper.MappedUser, error) {
+	updates, err := profileUpdates(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(updates) > 0 {
+		if err := us.userRepository.UpdateProfile(ctx, userID, updates); err != nil {
+			if errors.Is(err, repositories.ErrConflict) {
+				return nil, ErrUsernameTaken
+			}
+			return nil, err
+		}
+		us.invalidateCache(ctx, userID)
+	}
+
+	user, err := us.userRepository.GetByID(ctx, int(userID))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.UserMapper(user), nil
+}
+
+// GetPublicProfile returns the public profile and race statistics of an
+// activated user that is not being deleted.
+func (us *UsersService) GetPublicProfile(ctx context.Context, username string) (*mapper.MappedPublicProfile, error) {
+	user, err := us.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	// 탈퇴 유예 중인 계정도 공개하지 않음
+	if !user.IsActive || user.DeletionScheduledAt != nil {
+		return nil, ErrUserNotFound
+	}
+
+	profile := mapper.PublicProfileMapper(user)
+
+	played, wins, err := us.matchRepository.UserStats(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load match stats: %w", err)
+	}
+	profile.Stats.MatchesPlayed = played
+	profile.Stats.Wins = wins
+
+	languageID, ok, err := us.submissionRepository.FavouriteLanguage(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load favourite language: %w", err)
+	}
+	if ok {
+		profile.Stats.FavouriteLanguage = us.describeLanguage(ctx, languageID)
+	}
+
+	return profile, nil
+}
+
+func (us *UsersService) describeLanguage(ctx context.Context, languageID int) *mapper.MappedLanguage {
+	described := &mapper.MappedLanguage{ID: languageID}
+
+	// 비활성화된 언어도 이름은 보여줌
+	all, err := us.languagesService.GetLanguages(ctx, true)
+	if err != nil {
+		us.logger.Warnw("failed to load languages for profile", "error", err)
+		return described
+	}
+	for _, l := range all {
+		if l.ID == languageID {
+			described.Slug = l.Slug
+			described.Name = l.Name
+			break
+		}
+	}
+	return described
+}
+
+func (us *UsersService) invalidateCache(ctx context.Context, userID uint) {
+	if us.userStore == nil {
+		return
+	}
+	if err := us.userStore.Delete(ctx, int(userID)); err != nil {
+		us.logger.Warnw("failed to invalidate cached user", "userID", userID, "error", err)
+	}
+}
+
+// profileUpdates validates the request and returns the columns to update.
+func profileUpdates(dto dtos.UpdateProfileRequestDto) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if dto.Username != nil {
+		username := strings.TrimSpace(*dto.Username)
+		if err := utils.ValidateUsername(username); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+		}
+		updates["username"] = username
+	}
+
+	if dto.DisplayName != nil {
+		displayName := strings.TrimSpace(*dto.DisplayName)
+		if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+			return nil, fmt.Errorf("%w: display name must be at most %d characters", ErrInvalidProfile, maxDisplayNameLength)
+		}
+		updates["display_name"] = displayName
+	}
+
+	if dto.Bio != nil {
+		bio := strings.TrimSpace(*dto.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return nil, fmt.Errorf("%w: bio must be at most %d characters", ErrInvalidProfile, maxBioLength)
+		}
+		updates["bio"] = bio
+	}
+
+	if dto.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*dto.AvatarURL)
+		if avatarURL != "" {
+			if len(avatarURL) > maxAvatarURLLength {
+				return nil, fmt.Errorf("%w: avatar url is too long", ErrInvalidProfile)
+			}
+			u, err := url.Parse(avatarURL)
+			if err != nil || u.Scheme != "https" || u.Host == "" {
+				return nil, fmt.Errorf("%w: avatar url must be an https url", ErrInvalidProfile)
+			}
+		}
+		updates["avatar_url"] = avatarURL
+	}
+
+	if dto.PreferredLanguage != nil {
+		slug := strings.TrimSpace(*dto.PreferredLanguage)
+		if slug != "" {
+			if _, ok := langdefs.BySlug(slug); !ok {
+				return nil, fmt.Errorf("%w: unknown language %q", ErrInvalidProfile, slug)
+			}
+		}
+		updates["preferred_language"] = slug
+	}
+
+	if dto.EditorTheme != nil {
+		theme := strings.TrimSpace(*dto.EditorTheme)
+		if theme != "" && !editorThemes[theme] {
+			return nil, fmt.Errorf("%w: unknown editor theme %q", ErrInvalidProfile, theme)
+		}
+		updates["editor_theme"] = theme
+	}
+
+	if dto.Locale != nil {
+		locale := strings.TrimSpace(*dto.Locale)
+		if locale != "" {
+			normalized, ok := i18n.Normalize(locale)
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported locale %q (supported: %s)", ErrInvalidProfile, locale, strings.Join(i18n.Locales, ", "))
+			}
+			locale = normalized
+		}
+		updates["locale"] = locale
+	}
+
+	return updates, nil
+}
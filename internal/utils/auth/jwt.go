@@ -1,51 +1,174 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
-	"github.com/Dongmoon29/code_racer_api/internal/env"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-// JWT 토큰 생성
-func GenerateJWT(userID string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"user_id": userID,
-			"exp":     expirationTime.Unix(),
-		})
-
-	// 환경 변수에서 JWT_SECRET 키 가져오기
-	secretKey := env.GetString("JWT_SECRET", "secret")
-	tokenString, err := token.SignedString([]byte(secretKey))
+const (
+	// 액세스 토큰은 짧게, 리프레시 토큰으로 갱신
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+	// 리프레시 토큰을 계속 교체하더라도 세션은 이 기간이 지나면 만료
+	SessionMaxLifetime = 30 * 24 * time.Hour
+
+	EmailVerificationTTL = 24 * time.Hour
+)
+
+// 액션 토큰 용도
+const (
+	PurposeEmailVerification = "email_verification"
+)
+
+// AccessClaims are the claims of an access token. SessionID ties the token
+// to a server-side session so it can be revoked before it expires.
+type AccessClaims struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// ActionClaims are the claims of a token sent by email to confirm an action.
+// Purpose keeps a token from being used for anything else, and the missing
+// session id keeps it from being accepted as an access token.
+type ActionClaims struct {
+	UserID  string `json:"user_id"`
+	Email   string `json:"email"`
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+var signingSecret []byte
+
+// SetSecret sets the key tokens are signed with. It is called once at
+// startup, before any token is issued or parsed.
+func SetSecret(secret string) {
+	signingSecret = []byte(secret)
+}
+
+func secretKey() []byte {
+	return signingSecret
+}
+
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	// 서명 방법 확인
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return secretKey(), nil
+}
+
+// JWT 액세스 토큰 생성
+func GenerateAccessToken(userID string, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expirationTime := now.Add(AccessTokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, AccessClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
+	})
+
+	tokenString, err := token.SignedString(secretKey())
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
-	return tokenString, nil
+	return tokenString, expirationTime, nil
+}
+
+// JWT 액세스 토큰 검증 및 클레임 반환
+func ParseAccessToken(tokenString string) (*AccessClaims, error) {
+	claims := &AccessClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.UserID == "" || claims.SessionID == "" {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
 }
 
 // JWT 토큰 검증
 func VerifyToken(tokenString string) error {
-	secretKey := env.GetString("JWT_SECRET", "secret")
-
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// 서명 방법 확인
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secretKey), nil
+	_, err := ParseAccessToken(tokenString)
+	return err
+}
+
+// 이메일 링크용 액션 토큰 생성
+func GenerateActionToken(userID string, email string, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, ActionClaims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
 	})
+	return token.SignedString(secretKey())
+}
 
+// 액션 토큰 검증. 용도가 다르면 거부
+func ParseActionToken(tokenString string, purpose string) (*ActionClaims, error) {
+	claims := &ActionClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if !token.Valid || claims.Purpose != purpose || claims.UserID == "" {
+		return nil, fmt.Errorf("invalid token")
 	}
 
-	if !token.Valid {
-		return fmt.Errorf("invalid token")
+	return claims, nil
+}
+
+// GenerateOpaqueToken returns a random URL-safe token and its hash. Only the
+// hash is meant to be stored.
+func GenerateOpaqueToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken hashes an opaque token for storage and lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyPrefix marks personal API keys so they are easy to recognise, e.g.
+// by secret scanners.
+const APIKeyPrefix = "crk_"
+
+// GenerateAPIKey returns a new API key, the short prefix shown to the user to
+// identify it, and the hash to store.
+func GenerateAPIKey() (string, string, string, error) {
+	token, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", "", err
 	}
 
-	return nil
+	key := APIKeyPrefix + token
+	return key, key[:len(APIKeyPrefix)+8], HashToken(key), nil
 }
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	MinPasswordLength = 8
+	// bcrypt는 72바이트 이후를 무시함
+	MaxPasswordBytes = 72
+)
+
+var (
+	ErrPasswordTooShort = errors.New("password must be at least 8 characters long")
+	ErrPasswordTooLong  = errors.New("password must be at most 72 bytes long")
+	ErrPasswordTooWeak  = errors.New("password must contain at least one letter and one digit")
+	ErrPasswordContains = errors.New("password must not contain the username or email")
+)
+
+// ValidatePassword enforces the password policy. identifiers (username,
+// email) must not appear in the password.
+func ValidatePassword(password string, identifiers ...string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > MaxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrPasswordTooWeak
+	}
+
+	lower := strings.ToLower(password)
+	for _, id := range identifiers {
+		// 이메일은 로컬 파트만 비교
+		id, _, _ = strings.Cut(strings.ToLower(id), "@")
+		if len(id) >= 3 && strings.Contains(lower, id) {
+			return ErrPasswordContains
+		}
+	}
+
+	return nil
+}
+
+// IsPasswordPolicyError reports whether err was returned by ValidatePassword.
+func IsPasswordPolicyError(err error) bool {
+	return errors.Is(err, ErrPasswordTooShort) ||
+		errors.Is(err, ErrPasswordTooLong) ||
+		errors.Is(err, ErrPasswordTooWeak) ||
+		errors.Is(err, ErrPasswordContains)
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		identifiers []string
+		want        error
+	}{
+		{"valid", "racecar42", nil, nil},
+		{"valid with symbols", "p@ss w0rd!", nil, nil},
+		{"exactly min length", "abcdefg1", nil, nil},
+		{"one below min length", "abcdef1", nil, ErrPasswordTooShort},
+		{"empty", "", nil, ErrPasswordTooShort},
+		// 길이는 바이트가 아니라 문자 수로 셈
+		{"multibyte counts runes", "비밀번호비밀1", nil, ErrPasswordTooShort},
+		{"multibyte long enough", "비밀번호비밀번1", nil, nil},
+		{"exactly max bytes", strings.Repeat("a", 71) + "1", nil, nil},
+		{"over max bytes", strings.Repeat("a", 72) + "1", nil, ErrPasswordTooLong},
+		{"multibyte over max bytes", strings.Repeat("가", 24) + "1", nil, ErrPasswordTooLong},
+		{"letters only", "abcdefghij", nil, ErrPasswordTooWeak},
+		{"digits only", "1234567890", nil, ErrPasswordTooWeak},
+		{"symbols and digits", "!!!!1234", nil, ErrPasswordTooWeak},
+		{"non-latin letter counts", "пароль123", nil, nil},
+		{"contains username", "xxdongmoon1", []string{"dongmoon"}, ErrPasswordContains},
+		{"contains username any case", "DongMoon2024", []string{"dongmoon"}, ErrPasswordContains},
+		{"contains email local part", "racer.kim99", []string{"someone", "racer.kim@example.com"}, ErrPasswordContains},
+		{"email domain is allowed", "example123", []string{"racer@example.com"}, nil},
+		{"short identifier ignored", "abcdefg1", []string{"ab"}, nil},
+		{"empty identifier ignored", "abcdefg1", []string{""}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password, tt.identifiers...)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidatePassword(%q, %q) = %v, want %v", tt.password, tt.identifiers, err, tt.want)
+			}
+			if err != nil && !IsPasswordPolicyError(err) {
+				t.Errorf("IsPasswordPolicyError(%v) = false", err)
+			}
+		})
+	}
+}
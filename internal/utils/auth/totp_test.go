@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 부록 B 의 SHA1 시크릿 "12345678901234567890" (base32)
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		unix     int64
+		code     string
+		lastUsed int64
+		wantStep int64
+		wantOK   bool
+	}{
+		// RFC 6238 테스트 벡터 (8자리 값의 뒤 6자리)
+		{"rfc 59", 59, "287082", 0, 1, true},
+		{"rfc 1111111109", 1111111109, "081804", 0, 37037036, true},
+		{"rfc 1111111111", 1111111111, "050471", 0, 37037037, true},
+		{"rfc 1234567890", 1234567890, "005924", 0, 41152263, true},
+		{"rfc 2000000000", 2000000000, "279037", 0, 66666666, true},
+		{"rfc 20000000000", 20000000000, "353130", 0, 666666666, true},
+		{"spaces in code", 59, "287 082", 0, 1, true},
+		{"wrong code", 59, "287083", 0, 0, false},
+		{"too short", 59, "28708", 0, 0, false},
+		{"eight digits", 59, "94287082", 0, 0, false},
+
+		// 앞뒤 한 구간까지 허용
+		{"one step later", 1111111109 + 30, "081804", 0, 37037036, true},
+		{"one step earlier", 1111111109 - 30, "081804", 0, 37037036, true},
+		{"two steps later", 1111111109 + 60, "081804", 0, 0, false},
+		{"two steps earlier", 1111111109 - 60, "081804", 0, 0, false},
+
+		// 이미 사용한 구간(또는 그 이전)의 코드는 거부
+		{"replayed step", 1111111109, "081804", 37037036, 0, false},
+		{"later step used", 1111111109, "081804", 37037037, 0, false},
+		{"replayed within window", 1111111109 + 30, "081804", 37037036, 0, false},
+		{"earlier step used", 1111111109, "081804", 37037035, 37037036, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfcSecret, tt.code, time.Unix(tt.unix, 0), tt.lastUsed)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP(%q, t=%d, last=%d) = (%d, %v), want (%d, %v)",
+					tt.code, tt.unix, tt.lastUsed, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPSecret(t *testing.T) {
+	if _, ok := ValidateTOTP(strings.ToLower(rfcSecret), "287082", time.Unix(59, 0), 0); !ok {
+		t.Error("ValidateTOTP rejected a lowercase secret")
+	}
+	if _, ok := ValidateTOTP("not base32!", "287082", time.Unix(59, 0), 0); ok {
+		t.Error("ValidateTOTP accepted an undecodable secret")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	codes, err := GenerateRecoveryCodes(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 50 {
+		t.Fatalf("got %d codes, want 50", len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Fatalf("code %q is not formatted as xxxxx-xxxxx", code)
+		}
+		for _, r := range strings.Replace(code, "-", "", 1) {
+			if !strings.ContainsRune(alphabet, r) {
+				t.Fatalf("code %q contains %q outside the alphabet", code, r)
+			}
+		}
+		if seen[code] {
+			t.Fatalf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrUsernameInvalid  = errors.New("username must be 3-32 letters, digits, '_' or '-'")
+	ErrUsernameReserved = errors.New("username is reserved")
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,32}$`)
+
+// 공개 프로필(/users/:username)이 가려지지 않도록 /users 아래 고정 경로는 사용자 이름으로 쓸 수 없음
+var reservedUsernames = map[string]bool{
+	"2fa":      true,
+	"account":  true,
+	"api-keys": true,
+	"export":   true,
+	"logout":   true,
+	"oauth":    true,
+	"password": true,
+	"profile":  true,
+	"refresh":  true,
+	"signin":   true,
+	"signup":   true,
+	"verify":   true,
+}
+
+// IsReservedUsername reports whether username collides with a route under
+// /users, ignoring case.
+func IsReservedUsername(username string) bool {
+	return reservedUsernames[strings.ToLower(strings.TrimSpace(username))]
+}
+
+// ValidateUsername checks a username chosen at signup or in the profile.
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return ErrUsernameInvalid
+	}
+	if IsReservedUsername(username) {
+		return ErrUsernameReserved
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsReservedUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"profile", true},
+		{"Profile", true},
+		{" export ", true},
+		{"api-keys", true},
+		{"verify", true},
+		{"refresh", true},
+		{"2fa", true},
+		{"profiles", false},
+		{"racer", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReservedUsername(tt.username); got != tt.want {
+			t.Errorf("IsReservedUsername(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     error
+	}{
+		{"racer", nil},
+		{"race_car-42", nil},
+		{"abc", nil},
+		{strings.Repeat("a", 32), nil},
+		{"ab", ErrUsernameInvalid},
+		{strings.Repeat("a", 33), ErrUsernameInvalid},
+		{"race car", ErrUsernameInvalid},
+		{" racer", ErrUsernameInvalid},
+		{"racer!", ErrUsernameInvalid},
+		{"레이서레이서", ErrUsernameInvalid},
+		{"", ErrUsernameInvalid},
+		{"profile", ErrUsernameReserved},
+		{"Export", ErrUsernameReserved},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateUsername(tt.username); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateUsername(%q) = %v, want %v", tt.username, err, tt.want)
+		}
+	}
+}
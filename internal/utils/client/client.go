@@ -5,18 +5,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
-	"os"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type Judge0Client struct {
-	client *http.Client
-	apiKey string
-	host   string
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	host    string
+}
+
+// Judge0Config points the client at a Judge0 instance. Host and APIKey are
+// sent as RapidAPI headers and may be empty for a self-hosted instance.
+type Judge0Config struct {
+	BaseURL string
+	Host    string
+	APIKey  string
+	Timeout time.Duration
 }
 
 type RedisClientInterface interface {
@@ -30,57 +42,69 @@ type RedisClient struct {
 	client *redis.Client
 }
 
-var (
-	J0Client  *Judge0Client
-	RdsClient *RedisClient
-	once      sync.Once
-)
-
-func init() {
-	judge0apikey := os.Getenv("X_JUDGE0_KEY")
-	judge0Host := os.Getenv("X_JUDGE0_HOST")
-	rdsHost := os.Getenv("RDS_HOST")
-	rdsPort := os.Getenv("RDS_PORT")
+var J0Client = &Judge0Client{
+	client:  newHTTPClient(30 * time.Second),
+	baseURL: "https://judge0-ce.p.rapidapi.com",
+}
 
-	log.Printf("%s, %s", rdsHost, rdsPort)
+// newHTTPClient traces outgoing requests and propagates the trace context
+// of the request's context to Judge0.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+}
 
-	once.Do(func() {
-		RdsClient = &RedisClient{
-			client: redis.NewClient(&redis.Options{
-				// Addr: fmt.Sprintf("%s:%s", rdsHost, rdsPort),
-				Addr: "localhost:6379",
-			}),
-		}
-		J0Client = &Judge0Client{
-			client: &http.Client{},
-			apiKey: judge0apikey,
-			host:   judge0Host,
-		}
-	})
+// Configure replaces the shared Judge0 client. It is called once at startup.
+func Configure(cfg Judge0Config) {
+	J0Client = &Judge0Client{
+		client:  newHTTPClient(cfg.Timeout),
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:  cfg.APIKey,
+		host:    cfg.Host,
+	}
 }
 
 func (c *Judge0Client) GET(endpoint string) (*http.Response, error) {
-	return c.doRequest("GET", endpoint, nil)
+	return c.Do(context.Background(), "GET", endpoint, nil)
 }
 
 func (c *Judge0Client) POST(endpoint string, body interface{}) (*http.Response, error) {
-	return c.doRequest("POST", endpoint, body)
+	return c.Do(context.Background(), "POST", endpoint, body)
 }
 
 func (c *Judge0Client) PUT(endpoint string, body interface{}) (*http.Response, error) {
-	return c.doRequest("PUT", endpoint, body)
+	return c.Do(context.Background(), "PUT", endpoint, body)
 }
 
 func (c *Judge0Client) DELETE(endpoint string) (*http.Response, error) {
-	return c.doRequest("DELETE", endpoint, nil)
+	return c.Do(context.Background(), "DELETE", endpoint, nil)
 }
 
-func (c *Judge0Client) doRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+// Ping checks that Judge0 answers and accepts our credentials.
+func (c *Judge0Client) Ping(ctx context.Context) error {
+	resp, err := c.Do(ctx, "GET", "/about", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status from judge0: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Do sends a request bound to ctx, so it is cancelled with ctx and traced
+// as part of the caller's span.
+func (c *Judge0Client) Do(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	req, err := c.judge0Request(method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
-	return c.client.Do(req)
+	return c.client.Do(req.WithContext(ctx))
 }
 
 func (c *Judge0Client) judge0Request(method, endpoint string, body interface{}) (*http.Request, error) {
@@ -93,7 +117,7 @@ func (c *Judge0Client) judge0Request(method, endpoint string, body interface{})
 		}
 	}
 
-	url := fmt.Sprintf("https://%s%s", "judge0-ce.p.rapidapi.com", endpoint)
+	url := c.baseURL + endpoint
 
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(requestBody))
 	if err != nil {
@@ -101,8 +125,10 @@ func (c *Judge0Client) judge0Request(method, endpoint string, body interface{})
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-rapidapi-key", c.apiKey)
-	req.Header.Set("x-rapidapi-host", c.host)
+	if c.apiKey != "" {
+		req.Header.Set("x-rapidapi-key", c.apiKey)
+		req.Header.Set("x-rapidapi-host", c.host)
+	}
 
 	return req, nil
 }
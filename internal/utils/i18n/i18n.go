@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale of a problem's main statement unless the
+// author says otherwise, and the last resort when nothing else matches.
+const DefaultLocale = "en"
+
+// Locales are the locales statements can be written in.
+var Locales = []string{"en", "ko"}
+
+// Normalize returns the supported locale for a tag like "ko-KR" or "EN".
+func Normalize(locale string) (string, bool) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil {
+		return "", false
+	}
+	base, _ := tag.Base()
+	for _, l := range Locales {
+		if l == base.String() {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage returns the supported locales of an Accept-Language
+// header, most preferred first.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if locale, ok := Normalize(tag.String()); ok && !contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// Preferred combines a saved locale with the Accept-Language header; the
+// saved one wins.
+func Preferred(saved string, acceptLanguage string) []string {
+	locales := ParseAcceptLanguage(acceptLanguage)
+	if locale, ok := Normalize(saved); ok {
+		locales = append([]string{locale}, remove(locales, locale)...)
+	}
+	return locales
+}
+
+// Pick returns the first preferred locale that is available, or fallback.
+func Pick(preferred []string, available []string, fallback string) string {
+	for _, locale := range preferred {
+		if contains(available, locale) {
+			return locale
+		}
+	}
+	return fallback
+}
+
+func contains(locales []string, locale string) bool {
+	for _, l := range locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(locales []string, locale string) []string {
+	out := make([]string, 0, len(locales))
+	for _, l := range locales {
+		if l != locale {
+			out = append(out, l)
+		}
+	}
+	return out
+}
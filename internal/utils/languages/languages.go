@@ -1,5 +1,7 @@
 package languages
 
+import "strings"
+
 const (
 	JavaScript = 63
 	PHP        = 68
@@ -9,3 +11,103 @@ const (
 	Ruby       = 72
 	Python     = 71
 )
+
+// Definition describes a language we support in the editor, independent of
+// the Judge0 instance that executes it. Judge0 ids change between releases,
+// so definitions are matched against /languages by name prefix and identified
+// by Slug everywhere else.
+type Definition struct {
+	Slug       string
+	Name       string
+	NamePrefix string
+	DefaultID  int
+	MonacoMode string
+	Extension  string
+	Template   string
+}
+
+var Definitions = []Definition{
+	{
+		Slug:       "javascript",
+		Name:       "JavaScript",
+		NamePrefix: "JavaScript (Node.js",
+		DefaultID:  JavaScript,
+		MonacoMode: "javascript",
+		Extension:  ".js",
+		Template:   "const lines = require('fs').readFileSync(0, 'utf8').split('\\n');\n\nfunction solve(lines) {\n  // write your code here\n}\n\nsolve(lines);\n",
+	},
+	{
+		Slug:       "python",
+		Name:       "Python 3",
+		NamePrefix: "Python (3.",
+		DefaultID:  Python,
+		MonacoMode: "python",
+		Extension:  ".py",
+		Template:   "import sys\n\n\ndef solve():\n    data = sys.stdin.read().split()\n    # write your code here\n\n\nsolve()\n",
+	},
+	{
+		Slug:       "go",
+		Name:       "Go",
+		NamePrefix: "Go (",
+		DefaultID:  Go,
+		MonacoMode: "go",
+		Extension:  ".go",
+		Template:   "package main\n\nimport (\n\t\"bufio\"\n\t\"os\"\n)\n\nfunc main() {\n\treader := bufio.NewReader(os.Stdin)\n\t_ = reader\n\t// write your code here\n}\n",
+	},
+	{
+		Slug:       "java",
+		Name:       "Java",
+		NamePrefix: "Java (OpenJDK",
+		DefaultID:  Java,
+		MonacoMode: "java",
+		Extension:  ".java",
+		Template:   "import java.util.*;\n\npublic class Main {\n    public static void main(String[] args) {\n        Scanner sc = new Scanner(System.in);\n        // write your code here\n    }\n}\n",
+	},
+	{
+		Slug:       "ruby",
+		Name:       "Ruby",
+		NamePrefix: "Ruby (",
+		DefaultID:  Ruby,
+		MonacoMode: "ruby",
+		Extension:  ".rb",
+		Template:   "lines = STDIN.read.split(\"\\n\")\n# write your code here\n",
+	},
+	{
+		Slug:       "php",
+		Name:       "PHP",
+		NamePrefix: "PHP (",
+		DefaultID:  PHP,
+		MonacoMode: "php",
+		Extension:  ".php",
+		Template:   "<?php\n$lines = explode(\"\\n\", stream_get_contents(STDIN));\n// write your code here\n",
+	},
+	{
+		Slug:       "lua",
+		Name:       "Lua",
+		NamePrefix: "Lua (",
+		DefaultID:  Lua,
+		MonacoMode: "lua",
+		Extension:  ".lua",
+		Template:   "local input = io.read(\"*a\")\n-- write your code here\n",
+	},
+}
+
+// Match returns the definition whose NamePrefix matches a Judge0 language name.
+func Match(judge0Name string) (Definition, bool) {
+	for _, def := range Definitions {
+		if strings.HasPrefix(judge0Name, def.NamePrefix) {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// BySlug returns the definition registered under slug.
+func BySlug(slug string) (Definition, bool) {
+	for _, def := range Definitions {
+		if def.Slug == slug {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
@@ -0,0 +1,38 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough, mathExtension{}),
+	)
+
+	// 사용자 입력으로 취급: 원시 HTML, 스크립트, 이벤트 속성은 모두 제거
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^math (inline|display)$`)).OnElements("span", "div")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return p
+}
+
+// Render converts a problem statement to sanitized HTML. Fenced code blocks
+// keep their language class, and TeX between $...$ (inline) or $$...$$
+// (display) is emitted unchanged in <span class="math inline"> and
+// <div class="math display"> for the client to typeset.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return policy.Sanitize(buf.String()), nil
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"script tag", "<script>alert(1)</script>", "\n"},
+		{"script in paragraph", "hi <script>alert(1)</script> there", "<p>hi alert(1) there</p>\n"},
+		{"event handler", `<img src="a.png" onerror="alert(1)">`, "\n"},
+		{"event handler on allowed tag", `<a href="https://example.com" onclick="alert(1)">x</a>`, "<p>x</p>\n"},
+		{"javascript link", "[x](javascript:alert(1))", "<p>x</p>\n"},
+		{"forged math class", `<span class="math inline" onclick="alert(1)">a</span>`, "<p>a</p>\n"},
+
+		// 수식 안의 HTML 은 태그가 아니라 텍스트로 출력
+		{"script in inline math", "$<script>alert(1)</script>$", `<p><span class="math inline">&lt;script&gt;alert(1)&lt;/script&gt;</span></p>` + "\n"},
+		{"handler in display math", "$$<img src=x onerror=alert(1)>$$", `<div class="math display">&lt;img src=x onerror=alert(1)&gt;</div>` + "\n"},
+		{"script in math block", "$$\n<script>alert(1)</script>\n$$", `<div class="math display">&lt;script&gt;alert(1)&lt;/script&gt;</div>` + "\n"},
+
+		// 허용된 출력은 유지
+		{"inline math", "$x<y$ and $a$", `<p><span class="math inline">x&lt;y</span> and <span class="math inline">a</span></p>` + "\n"},
+		{"code language", "```go\nfmt.Println(1)\n```", `<pre><code class="language-go">fmt.Println(1)` + "\n</code></pre>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+			for _, bad := range []string{"<script", "<img", `onerror="`, `onclick="`, "javascript:"} {
+				if strings.Contains(got, bad) {
+					t.Errorf("Render(%q) = %q contains %q", tt.source, got, bad)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	kindMathInline = ast.NewNodeKind("MathInline")
+	kindMathBlock  = ast.NewNodeKind("MathBlock")
+)
+
+// mathInline is $...$, or $$...$$ inside a paragraph.
+type mathInline struct {
+	ast.BaseInline
+	tex     []byte
+	display bool
+}
+
+func (n *mathInline) Kind() ast.NodeKind { return kindMathInline }
+
+func (n *mathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TeX": string(n.tex)}, nil)
+}
+
+// mathBlock is a $$ ... $$ block; its lines hold the TeX.
+type mathBlock struct {
+	ast.BaseBlock
+	closed bool
+}
+
+func (n *mathBlock) Kind() ast.NodeKind { return kindMathBlock }
+func (n *mathBlock) IsRaw() bool        { return true }
+
+func (n *mathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+type mathInlineParser struct{}
+
+func (p mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p mathInlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	delim := 1
+	if len(line) > 1 && line[1] == '$' {
+		delim = 2
+	}
+
+	end := -1
+	for i := delim; i+delim <= len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '$' {
+			if delim == 2 && (i+1 >= len(line) || line[i+1] != '$') {
+				continue
+			}
+			end = i
+			break
+		}
+	}
+	if end <= delim {
+		return nil
+	}
+
+	tex := line[delim:end]
+	if delim == 1 {
+		// "$5 and $10" 같은 금액은 수식으로 보지 않음
+		if util.IsSpace(tex[0]) || util.IsSpace(tex[len(tex)-1]) {
+			return nil
+		}
+		if end+1 < len(line) && line[end+1] >= '0' && line[end+1] <= '9' {
+			return nil
+		}
+	}
+
+	block.Advance(end + delim)
+	return &mathInline{tex: append([]byte(nil), tex...), display: delim == 2}
+}
+
+type mathBlockParser struct{}
+
+func (p mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p mathBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], []byte("$$")) {
+		return nil, parser.NoChildren
+	}
+
+	node := &mathBlock{}
+	rest := util.TrimRightSpace(line[pos+2:])
+	switch {
+	case len(rest) == 0:
+	case len(rest) > 2 && bytes.HasSuffix(rest, []byte("$$")):
+		// $$ ... $$ 한 줄
+		start := segment.Start + pos + 2
+		node.Lines().Append(text.NewSegment(start, start+len(rest)-2))
+		node.closed = true
+	default:
+		return nil, parser.NoChildren
+	}
+
+	reader.Advance(segment.Len() - trailingNewline(line))
+	return node, parser.NoChildren
+}
+
+func (p mathBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	if node.(*mathBlock).closed {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	if bytes.Equal(util.TrimLeftSpace(util.TrimRightSpace(line)), []byte("$$")) {
+		reader.Advance(segment.Len() - trailingNewline(line))
+		return parser.Close
+	}
+
+	node.Lines().Append(segment)
+	reader.Advance(segment.Len() - trailingNewline(line))
+	return parser.Continue | parser.NoChildren
+}
+
+func (p mathBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p mathBlockParser) CanInterruptParagraph() bool { return true }
+
+func (p mathBlockParser) CanAcceptIndentedLine() bool { return false }
+
+func trailingNewline(line []byte) int {
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		return 1
+	}
+	return 0
+}
+
+// mathRenderer writes the TeX escaped, leaving typesetting to the client.
+type mathRenderer struct{}
+
+func (r mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMathInline, r.renderInline)
+	reg.Register(kindMathBlock, r.renderBlock)
+}
+
+func (r mathRenderer) renderInline(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	node := n.(*mathInline)
+	class := "math inline"
+	if node.display {
+		class = "math display"
+	}
+	w.WriteString(`<span class="` + class + `">`)
+	w.WriteString(html.EscapeString(string(node.tex)))
+	w.WriteString("</span>")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r mathRenderer) renderBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	var tex bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		tex.Write(seg.Value(source))
+	}
+	w.WriteString(`<div class="math display">`)
+	w.WriteString(html.EscapeString(string(bytes.TrimSpace(tex.Bytes()))))
+	w.WriteString("</div>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+type mathExtension struct{}
+
+func (e mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(mathBlockParser{}, 150)),
+		parser.WithInlineParsers(util.Prioritized(mathInlineParser{}, 150)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(mathRenderer{}, 150)))
+}